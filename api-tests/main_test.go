@@ -0,0 +1,16 @@
+package api_tests_test
+
+import (
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	verifier.Defaults(
+		verifier.UseDirectory("../_testdata"),
+		verifier.DisableDiff(),
+	)
+
+	os.Exit(verifier.RunWithOrphanDetection(m, verifier.OrphanOptions{}))
+}
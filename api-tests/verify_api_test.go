@@ -1,8 +1,11 @@
 package api_tests_test
 
 import (
+	"errors"
+	"github.com/VerifyTests/Verify.Go/utils"
 	"github.com/VerifyTests/Verify.Go/verifier"
 	"github.com/google/uuid"
+	"path"
 	"strconv"
 	"strings"
 	"testing"
@@ -43,16 +46,8 @@ func (t Title) String() string {
 	return "Undefined Title"
 }
 
-func DefaultTestConfiguration() []verifier.VerifyConfigure {
-	return []verifier.VerifyConfigure{
-		verifier.UseDirectory("../_testdata"),
-		verifier.DisableDiff(),
-	}
-}
-
-func NewTestVerifier(t *testing.T) verifier.Verifier {
-	cfg := DefaultTestConfiguration()
-	return verifier.NewVerifier(t).Configure(cfg...)
+func NewTestVerifier(t *testing.T) verifier.ExtendedVerifier {
+	return verifier.NewExtendedVerifier(t)
 }
 
 func TestVerifyingNilObject(t *testing.T) {
@@ -260,3 +255,62 @@ func TestUsingTableTestsWithSubTestsAndExplicitTestCaseName(t *testing.T) {
 		})
 	}
 }
+
+func TestUsingFileConvention(t *testing.T) {
+	snapshots := func(info verifier.FileNameInfo) (string, string) {
+		return path.Join(info.TestName, info.TestCase), path.Join(info.Directory, "snapshots", info.TypeName)
+	}
+
+	for _, name := range []string{"first", "second"} {
+		t.Run(name, func(t *testing.T) {
+			NewTestVerifier(t).
+				Configure(verifier.UseFileConvention(snapshots)).
+				Verify(name)
+		})
+	}
+}
+
+func TestUsingFileName(t *testing.T) {
+	NewTestVerifier(t).
+		Configure(verifier.UseFileName("CustomFileName")).
+		Verify("Foo")
+}
+
+func TestUsingTypeAndMethodName(t *testing.T) {
+	NewTestVerifier(t).
+		Configure(verifier.UseTypeName("CustomType"), verifier.UseMethodName("CustomMethod")).
+		Verify("Foo")
+}
+
+func TestUsingPackageDefaults(t *testing.T) {
+	verifier.Verify(t, "Foo")
+}
+
+func TestVerifyE(t *testing.T) {
+	directory := t.TempDir()
+	v := verifier.NewExtendedVerifier(t, verifier.UseDirectory(directory), verifier.DisableRequireUniquePrefix())
+
+	err := v.VerifyE("Foo")
+
+	var verificationError *verifier.VerificationError
+	if !errors.As(err, &verificationError) {
+		t.Fatalf("Should return a VerificationError, got %v", err)
+	}
+	if len(verificationError.NewFiles) != 1 || len(verificationError.NotEqualFiles) != 0 {
+		t.Fatalf("Should report the new file")
+	}
+	if !strings.Contains(err.Error(), verificationError.NewFiles[0].ReceivedName) {
+		t.Fatalf("Should contain the failure message: %s", err.Error())
+	}
+
+	file := verificationError.NewFiles[0]
+	utils.File.Move(file.ReceivedPath, file.VerifiedPath)
+
+	if err = v.VerifyE("Bar"); err == nil || len(err.(*verifier.VerificationError).NotEqualFiles) != 1 {
+		t.Fatalf("Should report the changed file, got %v", err)
+	}
+
+	if err = v.VerifyE("Foo"); err != nil {
+		t.Fatalf("Should return nil for equal files, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package api_tests_test
+
+import (
+	"errors"
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"testing"
+)
+
+type price struct {
+	Net   int
+	Gross float64
+}
+
+func calculatePrice(quantity int, customer string, discount float64) (price, error) {
+	if quantity <= 0 {
+		return price{}, errors.New("quantity must be positive")
+	}
+	if discount > 0.5 {
+		panic("discount is too high")
+	}
+
+	net := quantity * 10
+	if customer == "vip" {
+		net -= quantity
+	}
+	return price{Net: net, Gross: float64(net) * (1 - discount)}, nil
+}
+
+func TestVerifyPriceCombinations(t *testing.T) {
+	verifier.VerifyCombinations(t, calculatePrice,
+		[]int{0, 1, 25},
+		[]string{"regular", "vip"},
+		[]float64{0, 0.25, 0.75})
+}
+
+func TestVerifyValidationCombinations(t *testing.T) {
+	validate := func(name string, age int) error {
+		if len(name) == 0 {
+			return errors.New("name is required")
+		}
+		if age < 18 {
+			return errors.New("must be an adult")
+		}
+		return nil
+	}
+
+	NewTestVerifier(t).VerifyCombinations(validate, []string{"", "Jo", "Ünïcödé"}, [2]int{17, 120})
+}
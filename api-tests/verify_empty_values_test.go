@@ -0,0 +1,41 @@
+package api_tests_test
+
+import (
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"testing"
+)
+
+type inventory struct {
+	Name       string
+	Count      int
+	Discount   float64
+	Active     bool
+	Parent     *inventory
+	Tags       []string
+	Locations  []string
+	Attributes map[string]string
+	Metadata   map[string]string
+	Comment    string `json:"comment,omitempty"`
+}
+
+func newInventory() inventory {
+	return inventory{
+		Name:     "warehouse",
+		Tags:     nil,
+		Metadata: map[string]string{},
+		// present, but empty
+		Locations: []string{},
+	}
+}
+
+func TestVerifyIgnoringEmptyValues(t *testing.T) {
+	NewTestVerifier(t).Verify(newInventory())
+}
+
+func TestVerifyIncludingDefaults(t *testing.T) {
+	NewTestVerifier(t).Configure(verifier.IncludeDefaults()).Verify(newInventory())
+}
+
+func TestVerifyJSONIgnoringEmptyValues(t *testing.T) {
+	NewTestVerifier(t).Configure(verifier.UseStrictJSON(), verifier.IgnoreEmptyValues()).Verify(newInventory())
+}
@@ -0,0 +1,77 @@
+package api_tests_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"testing"
+)
+
+type NotFoundError struct {
+	Resource string
+	ID       int
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %d not found: %s", e.Resource, e.ID, e.Err)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// multiError wraps several errors, like errors.Join
+type multiError struct {
+	errs []error
+}
+
+func (e *multiError) Error() string {
+	message := ""
+	for i, err := range e.errs {
+		if i > 0 {
+			message += "\n"
+		}
+		message += err.Error()
+	}
+	return message
+}
+
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
+
+var errNoRows = errors.New("no rows in result set")
+
+func findCustomer(id int) error {
+	return fmt.Errorf("get customer: %w", &NotFoundError{Resource: "customer", ID: id, Err: errNoRows})
+}
+
+func TestVerifyWrappedError(t *testing.T) {
+	verifier.VerifyError(t, findCustomer(42))
+}
+
+func TestVerifyJoinedErrors(t *testing.T) {
+	err := &multiError{errs: []error{
+		findCustomer(1),
+		fmt.Errorf("retry: %w", errors.New("connection reset")),
+	}}
+	NewTestVerifier(t).VerifyError(err)
+}
+
+func TestVerifyErrorAsTarget(t *testing.T) {
+	NewTestVerifier(t).Verify(&NotFoundError{Resource: "order", ID: 7, Err: errNoRows})
+}
+
+func TestVerifyPanicsWithError(t *testing.T) {
+	NewTestVerifier(t).VerifyPanics(func() {
+		panic(findCustomer(3))
+	})
+}
+
+func TestVerifyPanicsWithValue(t *testing.T) {
+	NewTestVerifier(t).VerifyPanics(func() {
+		var values []int
+		_ = values[5]
+	})
+}
@@ -0,0 +1,72 @@
+package api_tests_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+)
+
+type zipEntry struct {
+	Name string
+	Size uint64
+}
+
+// convertZip verifies the entries of the archive as separate files
+func convertZip(data []byte) ([]verifier.Target, interface{}) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		panic(err)
+	}
+
+	targets := make([]verifier.Target, 0)
+	entries := make([]zipEntry, 0)
+	for _, file := range reader.File {
+		content, err := file.Open()
+		if err != nil {
+			panic(err)
+		}
+		entry, _ := ioutil.ReadAll(content)
+		_ = content.Close()
+
+		extension := strings.TrimPrefix(path.Ext(file.Name), ".")
+		if extension == "txt" || extension == "csv" {
+			targets = append(targets, verifier.NewStringTarget(extension, string(entry)))
+		} else {
+			targets = append(targets, verifier.NewStreamTarget(extension, entry))
+		}
+		entries = append(entries, zipEntry{Name: file.Name, Size: file.UncompressedSize64})
+	}
+
+	return targets, entries
+}
+
+func createZip(files map[string]string, names ...string) io.Reader {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, name := range names {
+		file, _ := writer.Create(name)
+		_, _ = file.Write([]byte(files[name]))
+	}
+	_ = writer.Close()
+	return bytes.NewReader(buffer.Bytes())
+}
+
+func TestVerifyWithFileConverter(t *testing.T) {
+	archive := createZip(map[string]string{
+		"readme.txt": "Monthly report",
+		"sales.csv":  "month,total\njanuary,120\nfebruary,95",
+		"logo.bin":   "\x00\x01\x02",
+	}, "readme.txt", "sales.csv", "logo.bin")
+
+	NewTestVerifier(t).
+		Configure(
+			verifier.UseExtension("zip"),
+			verifier.AddFileConverter("zip", convertZip),
+		).
+		Verify(archive)
+}
@@ -0,0 +1,67 @@
+package api_tests_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+type roundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func inventoryTransport(request *http.Request) (*http.Response, error) {
+	if request.URL.Path == "/offline" {
+		return nil, errors.New("connection refused")
+	}
+
+	body := `{"sku":"a-42","reserved":true,"reservationId":"e3b0c442-98fc-1c14-9afb-f4c8996fb924","expires":"2022-01-02T15:04:05Z"}`
+	return &http.Response{
+		Status:     "201 Created",
+		StatusCode: http.StatusCreated,
+		Proto:      "HTTP/1.1",
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+			"Date":         []string{"Mon, 02 Jan 2006 15:04:05 GMT"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+type order struct {
+	SKU      string
+	Reserved bool
+}
+
+func placeOrder(client *http.Client, sku string) order {
+	request, _ := json.Marshal(map[string]interface{}{"sku": sku, "quantity": 2})
+	response, err := client.Post("https://inventory.example.com/reservations", "application/json", bytes.NewReader(request))
+	if err != nil {
+		return order{SKU: sku}
+	}
+	defer response.Body.Close()
+
+	var reservation struct {
+		Reserved bool `json:"reserved"`
+	}
+	_ = json.NewDecoder(response.Body).Decode(&reservation)
+
+	_, _ = client.Get("https://inventory.example.com/offline")
+	return order{SKU: sku, Reserved: reservation.Reserved}
+}
+
+func TestVerifyWithHTTPCalls(t *testing.T) {
+	recorder := verifier.NewHTTPRecorder(roundTripFunc(inventoryTransport))
+
+	result := placeOrder(recorder.Client(), "a-42")
+
+	NewTestVerifier(t).
+		Configure(verifier.AppendHTTPCalls(recorder)).
+		Verify(result)
+}
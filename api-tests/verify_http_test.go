@@ -0,0 +1,77 @@
+package api_tests_test
+
+import (
+	"bytes"
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func customerHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+	w.Header().Set("ETag", `"33a64df5"`)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: "a1b2c3", Path: "/", HttpOnly: true})
+	_, _ = w.Write([]byte(`{"id":42,"name":"John","tags":["new","vip"]}`))
+}
+
+func TestVerifyHandlerWithJSON(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/customers/42", nil)
+	verifier.VerifyHandler(t, http.HandlerFunc(customerHandler), request)
+}
+
+func TestVerifyHandlerWithXML(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><customer id="42"><name>John &amp; Jane</name><tags><tag>new</tag><tag/></tags></customer>`))
+	})
+
+	NewTestVerifier(t).VerifyHandler(handler, httptest.NewRequest(http.MethodGet, "/customers/42", nil))
+}
+
+func TestVerifyHandlerWithHTML(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<!DOCTYPE html><html><head><title>Not Found</title></head><body><p>The page <b>/missing</b> was not found.<br></p></body></html>`))
+	})
+
+	NewTestVerifier(t).VerifyHandler(handler, httptest.NewRequest(http.MethodGet, "/missing", nil))
+}
+
+func TestVerifyHTTPResponseWithBinaryBody(t *testing.T) {
+	buffer := bytes.Buffer{}
+	_ = png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 2, 2)))
+
+	response := &http.Response{
+		Proto:      "HTTP/1.1",
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":   []string{"image/png"},
+			"Content-Length": []string{"68"},
+			"Cache-Control":  []string{"no-cache"},
+		},
+		Body: ioutil.NopCloser(&buffer),
+	}
+
+	NewTestVerifier(t).VerifyHTTPResponse(response)
+}
+
+func TestVerifyHTTPResponseKeepingHeaders(t *testing.T) {
+	response := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header: http.Header{
+			"Content-Length": []string{"5"},
+			"X-Request-Id":   []string{"f81d4fae"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString("hello")),
+	}
+
+	verifier.NewExtendedVerifier(t, verifier.DontScrubHTTPHeaders("content-length"), verifier.ScrubHTTPHeaders("X-Request-ID")).
+		VerifyHTTPResponse(response)
+}
@@ -0,0 +1,56 @@
+package api_tests_test
+
+import (
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"testing"
+	"time"
+)
+
+type auditInfo struct {
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+type account struct {
+	Name         string
+	Email        string `json:"email"`
+	Password     string
+	APIKey       string `verify:"scrub"`
+	InternalNote string `verify:"ignore"`
+	LastLogin    time.Time
+	Audit        *auditInfo
+	Owner        accountOwner
+}
+
+type accountOwner struct {
+	Name  string
+	Audit auditInfo
+}
+
+func newAccount() account {
+	return account{
+		Name:         "orders",
+		Email:        "orders@example.com",
+		Password:     "hunter2",
+		APIKey:       "key-123",
+		InternalNote: "do not show",
+		LastLogin:    time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC),
+		Audit:        &auditInfo{CreatedBy: "admin"},
+		Owner:        accountOwner{Name: "John", Audit: auditInfo{CreatedBy: "system"}},
+	}
+}
+
+func TestVerifyWithMemberRules(t *testing.T) {
+	NewTestVerifier(t).
+		Configure(
+			verifier.IgnoreMember(account{}, "LastLogin"),
+			verifier.IgnoreMember(&accountOwner{}, "Name"),
+			verifier.IgnoreMembersWithType(auditInfo{}),
+			verifier.ScrubMember("Password"),
+		).
+		Verify(newAccount())
+}
+
+func TestVerifyWithMemberTags(t *testing.T) {
+	NewTestVerifier(t).Verify(newAccount())
+}
@@ -0,0 +1,62 @@
+package api_tests_test
+
+import (
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"github.com/google/uuid"
+	"sync"
+	"testing"
+)
+
+type cart struct {
+	Items []string
+	Total int
+}
+
+func checkout(items map[string]int) cart {
+	result := cart{}
+	for _, name := range []string{"apple", "pear"} {
+		price, found := items[name]
+		if !found {
+			continue
+		}
+		result.Items = append(result.Items, name)
+		result.Total += price
+		verifier.Recording.Add("subtotal", result.Total)
+	}
+	verifier.Recording.Add("paymentId", uuid.MustParse("1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"))
+	verifier.Recording.AppendFile("receipt", []byte("apple 3\npear 4\n"), "csv")
+	return result
+}
+
+func TestVerifyWithRecording(t *testing.T) {
+	verifier.Recording.Start(t)
+
+	result := checkout(map[string]int{"apple": 3, "pear": 4})
+
+	NewTestVerifier(t).Verify(result)
+}
+
+func TestRecordingFromGoroutines(t *testing.T) {
+	verifier.Recording.Start(t)
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			verifier.Recording.Add("worker", "done")
+		}()
+	}
+	group.Wait()
+
+	NewTestVerifier(t).Verify("all workers completed")
+}
+
+func TestAddWithoutRecording(t *testing.T) {
+	result := checkout(map[string]int{"apple": 3})
+	if verifier.Recording.IsRecording() {
+		t.Fatalf("Should not be recording")
+	}
+
+	NewTestVerifier(t).Verify(result)
+}
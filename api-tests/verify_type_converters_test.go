@@ -0,0 +1,48 @@
+package api_tests_test
+
+import (
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"testing"
+	"time"
+)
+
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+type invoice struct {
+	Number  string
+	Total   money
+	Issued  time.Time
+	Refunds []money
+}
+
+func writeMoney(value interface{}, writer *verifier.Writer) {
+	amount := value.(money)
+	writer.WriteString(fmt.Sprintf("%d.%02d %s", amount.Amount/100, amount.Amount%100, amount.Currency))
+}
+
+func TestVerifyWithConverter(t *testing.T) {
+	NewTestVerifier(t).
+		Configure(verifier.AddConverter(money{}, writeMoney)).
+		Verify(invoice{
+			Number:  "INV-1",
+			Total:   money{Amount: 12050, Currency: "EUR"},
+			Issued:  time.Date(2022, 3, 4, 10, 0, 0, 0, time.UTC),
+			Refunds: []money{{Amount: 999, Currency: "EUR"}},
+		})
+}
+
+func TestVerifyConvertedTarget(t *testing.T) {
+	NewTestVerifier(t).
+		Configure(verifier.AddConverter(invoice{}, func(value interface{}, writer *verifier.Writer) {
+			converted := value.(invoice)
+			writer.WriteValue(map[string]interface{}{
+				"number": converted.Number,
+				"issued": converted.Issued,
+			})
+		})).
+		Verify(&invoice{Number: "INV-2", Issued: time.Date(2022, 3, 4, 10, 0, 0, 0, time.UTC)})
+}
@@ -0,0 +1,50 @@
+package api_tests_test
+
+import (
+	"github.com/VerifyTests/Verify.Go/verifier"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+type serviceConfig struct {
+	Name     string
+	ID       uuid.UUID
+	Started  time.Time
+	Replicas int
+	Enabled  bool
+	Version  string
+	Notes    string
+	Ports    []int
+	Labels   map[string]string
+	Database struct {
+		Host    string
+		Options map[string]interface{}
+	}
+	Empty []string
+}
+
+func TestVerifyYAML(t *testing.T) {
+	config := serviceConfig{
+		Name:     "orders",
+		ID:       uuid.MustParse("1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"),
+		Started:  time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC),
+		Replicas: 3,
+		Enabled:  true,
+		Version:  "1.10",
+		Notes:    "first line\nsecond line",
+		Ports:    []int{80, 443},
+		Labels:   map[string]string{"tier": "backend", "env": "yes"},
+	}
+	config.Database.Host = "db:5432"
+	config.Database.Options = map[string]interface{}{"timeout": 30, "ssl": true}
+
+	NewTestVerifier(t).Configure(verifier.UseYAML()).Verify(config)
+}
+
+func TestVerifyYAMLWithAppenders(t *testing.T) {
+	verifier.Recording.Start(t)
+	verifier.Recording.Add("step", "loaded")
+
+	NewTestVerifier(t).Configure(verifier.UseYAML()).Verify([]string{"a", "b"})
+}
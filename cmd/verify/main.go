@@ -0,0 +1,194 @@
+// Command verify lists, accepts and rejects the pending received files of the snapshot tests.
+//
+// Usage:
+//
+//	verify [-root directory] [-received directory] list
+//	verify [-root directory] diff [pattern...]
+//	verify [-root directory] accept (-all | pattern...)
+//	verify [-root directory] reject (-all | pattern...)
+//
+// The patterns are globs that match the received or verified file path, relative to the root, or the file name.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/internal/pending"
+	"github.com/VerifyTests/Verify.Go/internal/textdiff"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const usage = `Usage: verify [-root directory] [-received directory] <command> [arguments]
+
+Commands:
+  list                        lists the pending received files
+  diff   [-all | pattern...]  shows the differences of the pending text files
+  accept (-all | pattern...)  replaces the verified files with the received files
+  reject (-all | pattern...)  deletes the received files
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	root := flags.String("root", ".", "the directory to search for the received files")
+	received := flags.String("received", "", "the received directory, when the received files are not stored next to the verified files")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	receivedRoot := *root
+	if len(*received) > 0 {
+		receivedRoot = *received
+	}
+
+	files, err := pending.FindMirrored(receivedRoot, *root)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Failed to search %s: %s\n", receivedRoot, err)
+		return 1
+	}
+
+	command := flags.Arg(0)
+	commandArgs := flags.Args()[1:]
+
+	switch command {
+	case "list":
+		list(stdout, *root, files)
+		return 0
+	case "diff":
+		selected, err := selectFiles(*root, files, commandArgs, false)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 2
+		}
+		for _, file := range selected {
+			showDiff(stdout, *root, file)
+		}
+		return 0
+	case "accept", "reject":
+		selected, err := selectFiles(*root, files, commandArgs, true)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 2
+		}
+		for _, file := range selected {
+			if command == "accept" {
+				file.Accept()
+				_, _ = fmt.Fprintf(stdout, "Accepted %s\n", relativePath(*root, file.VerifiedPath))
+			} else {
+				file.Reject()
+				_, _ = fmt.Fprintf(stdout, "Rejected %s\n", relativePath(*root, file.ReceivedPath))
+			}
+		}
+		return 0
+	}
+
+	_, _ = fmt.Fprintf(stderr, "Unknown command: %s\n", command)
+	flags.Usage()
+	return 2
+}
+
+func list(stdout io.Writer, root string, files []pending.File) {
+	if len(files) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No pending files.")
+		return
+	}
+
+	for _, file := range files {
+		state := "changed"
+		if file.IsNew() {
+			state = "new"
+		}
+		_, _ = fmt.Fprintf(stdout, "%-8s %s\n", state, relativePath(root, file.ReceivedPath))
+	}
+	_, _ = fmt.Fprintf(stdout, "%d pending file(s)\n", len(files))
+}
+
+func showDiff(stdout io.Writer, root string, file pending.File) {
+	verifiedName := relativePath(root, file.VerifiedPath)
+	receivedName := relativePath(root, file.ReceivedPath)
+
+	if !file.IsText() {
+		_, _ = fmt.Fprintf(stdout, "Binary files %s and %s differ\n", verifiedName, receivedName)
+		return
+	}
+
+	verified := ""
+	if !file.IsNew() {
+		verified = string(utils.File.ReadFile(file.VerifiedPath))
+	}
+	received := string(utils.File.ReadFile(file.ReceivedPath))
+
+	_, _ = fmt.Fprint(stdout, textdiff.Unified(verifiedName, receivedName, verified, received, textdiff.Options{
+		ContextLines: textdiff.DefaultContextLines,
+		UseColor:     textdiff.IsColorTerminal(),
+		MaxHunkLines: textdiff.DefaultMaxHunkLines,
+	}))
+}
+
+// selectFiles selects the pending files that match any of the patterns
+func selectFiles(root string, files []pending.File, args []string, requirePattern bool) ([]pending.File, error) {
+	selectFlags := flag.NewFlagSet("select", flag.ContinueOnError)
+	all := selectFlags.Bool("all", false, "select all the pending files")
+	if err := selectFlags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	patterns := selectFlags.Args()
+	if *all || (len(patterns) == 0 && !requirePattern) {
+		return files, nil
+	}
+	if len(patterns) == 0 {
+		return nil, errors.New("specify the files to select with patterns, or use -all")
+	}
+
+	selected := make([]pending.File, 0)
+	for _, file := range files {
+		if matchesAny(root, file, patterns) {
+			selected = append(selected, file)
+		}
+	}
+	return selected, nil
+}
+
+func matchesAny(root string, file pending.File, patterns []string) bool {
+	candidates := []string{
+		relativePath(root, file.ReceivedPath),
+		relativePath(root, file.VerifiedPath),
+		filepath.Base(file.ReceivedPath),
+		filepath.Base(file.VerifiedPath),
+	}
+
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if matched, _ := filepath.Match(filepath.ToSlash(pattern), candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func relativePath(root string, path string) string {
+	relative, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(relative)
+}
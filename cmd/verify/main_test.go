@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func createPendingFiles(t *testing.T) string {
+	root := t.TempDir()
+	utils.File.WriteText(filepath.Join(root, "a_test.First.verified.txt"), "old")
+	utils.File.WriteText(filepath.Join(root, "a_test.First.received.txt"), "new")
+	_ = utils.File.CreateDirectory(filepath.Join(root, "pkg"))
+	utils.File.WriteText(filepath.Join(root, "pkg", "b_test.Second.00.received.txt"), "second")
+	return root
+}
+
+func runCommand(t *testing.T, args ...string) (int, string) {
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String() + stderr.String()
+}
+
+func TestList(t *testing.T) {
+	root := createPendingFiles(t)
+
+	code, output := runCommand(t, "-root", root, "list")
+	if code != 0 {
+		t.Fatalf("Should succeed: %s", output)
+	}
+	if !strings.Contains(output, "changed  a_test.First.received.txt") ||
+		!strings.Contains(output, "new      pkg/b_test.Second.00.received.txt") {
+		t.Fatalf("Should list the pending files:\n%s", output)
+	}
+}
+
+func TestAcceptByPattern(t *testing.T) {
+	root := createPendingFiles(t)
+
+	code, output := runCommand(t, "-root", root, "accept", "*First*")
+	if code != 0 {
+		t.Fatalf("Should succeed: %s", output)
+	}
+
+	if string(utils.File.ReadFile(filepath.Join(root, "a_test.First.verified.txt"))) != "new" {
+		t.Fatalf("Should accept the matching file")
+	}
+	if !utils.File.Exists(filepath.Join(root, "pkg", "b_test.Second.00.received.txt")) {
+		t.Fatalf("Should keep the other files")
+	}
+}
+
+func TestRejectAll(t *testing.T) {
+	root := createPendingFiles(t)
+
+	if code, output := runCommand(t, "-root", root, "reject"); code != 2 {
+		t.Fatalf("Should require a pattern or -all: %s", output)
+	}
+
+	code, output := runCommand(t, "-root", root, "reject", "-all")
+	if code != 0 {
+		t.Fatalf("Should succeed: %s", output)
+	}
+	if _, output = runCommand(t, "-root", root, "list"); !strings.Contains(output, "No pending files.") {
+		t.Fatalf("Should reject all the files:\n%s", output)
+	}
+	if string(utils.File.ReadFile(filepath.Join(root, "a_test.First.verified.txt"))) != "old" {
+		t.Fatalf("Should keep the verified file")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	root := createPendingFiles(t)
+
+	_, output := runCommand(t, "-root", root, "diff", "a_test.*")
+	if !strings.Contains(output, "-old") || !strings.Contains(output, "+new") {
+		t.Fatalf("Should show the diff:\n%s", output)
+	}
+}
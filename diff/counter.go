@@ -28,7 +28,7 @@ func (c *instanceCounter) ReachedMax() bool {
 }
 
 func getMaxInstances(reader EnvReader) int {
-	variable, found := reader.LookupEnv("DiffEngine_MaxInstances")
+	variable, found := reader.LookupEnv(envMaxInstances)
 	if !found {
 		return defaultMaxInstance
 	}
@@ -10,11 +10,12 @@ const (
 
 // CheckCI checks for Continuous Integration environment being present
 func CheckCI() CIDetected {
-	return checkCI(&systemEnvReader{})
+	return checkCI(newEnvReader())
 }
 
+// CheckDisabled checks if launching the diff tools is disabled, or a Continuous Integration environment is present
 func CheckDisabled() bool {
-	return checkDisabled(&systemEnvReader{})
+	return checkDisabled(newEnvReader())
 }
 
 func checkDisabled(reader EnvReader) bool {
@@ -32,7 +32,7 @@ func (r *ResolvedTool) commandAndArguments(tempFile, targetFile string) (argumen
 func (r *ResolvedTool) getArguments(tempFile, targetFile string) []string {
 	tmp := utils.File.GetFullPath(tempFile)
 	tgt := utils.File.GetFullPath(targetFile)
-	if position.TargetOnLeft {
+	if getTargetPosition().TargetOnLeft {
 		return r.LeftArguments(tmp, tgt)
 	}
 	return r.RightArguments(tmp, tgt)
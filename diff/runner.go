@@ -27,14 +27,14 @@ func (s *systemEnvReader) LookupEnv(key string) (string, bool) {
 
 // Launch a new diff tool
 func Launch(tempFile, targetFile string) LaunchResult {
-	runner := newRunner(&systemEnvReader{})
+	runner := newRunner(newEnvReader())
 	return runner.Launch(tempFile, targetFile)
 }
 
 // Kill the diff tool if it doesn't support MDI, is already running and has been
 // opened to display a specific temp and target file.
 func Kill(tempFile, targetFile string) {
-	envReader := &systemEnvReader{}
+	envReader := newEnvReader()
 
 	if checkDisabled(envReader) {
 		return
@@ -0,0 +1,173 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	envToolOrder    = "DiffEngine_ToolOrder"
+	envMaxInstances = "DiffEngine_MaxInstances"
+	envTargetOnLeft = "DiffEngine_TargetOnLeft"
+
+	tempFilePlaceholder   = "{tempFile}"
+	targetFilePlaceholder = "{targetFile}"
+)
+
+// Settings configures the diff tools. The environment variables take precedence over these settings.
+type Settings struct {
+	// Disabled disables launching the diff tools, same as `DiffEngine_Disabled`
+	Disabled bool
+	// ToolOrder the preferred order of the diff tools, same as `DiffEngine_ToolOrder`
+	ToolOrder []ToolKind
+	// MaxInstances the maximum number of diff tools to launch, same as `DiffEngine_MaxInstances`
+	MaxInstances int
+	// TargetOnLeft shows the verified file on the left side, same as `DiffEngine_TargetOnLeft`
+	TargetOnLeft *bool
+	// CustomTools diff tools that are used before the known tools
+	CustomTools []CustomTool
+}
+
+// CustomTool a diff tool that is not part of the known tools.
+// The Arguments can use the `{tempFile}` and `{targetFile}` placeholders.
+type CustomTool struct {
+	Name             string
+	ExePath          string
+	Arguments        []string
+	AutoRefresh      bool
+	IsMdi            bool
+	SupportsText     bool
+	RequiresTarget   bool
+	BinaryExtensions []string
+}
+
+var settingsLocker = &sync.RWMutex{}
+var globalSettings = Settings{}
+
+// UseSettings validates and applies the settings to the diff tools launched afterwards
+func UseSettings(settings Settings) error {
+	if err := validateSettings(settings); err != nil {
+		return err
+	}
+
+	settingsLocker.Lock()
+	defer settingsLocker.Unlock()
+
+	globalSettings = settings
+	position = newTargetPosition(&settingsEnvReader{
+		reader:   &systemEnvReader{},
+		settings: settings,
+	})
+	return nil
+}
+
+// ValidateSettings checks the settings without applying them
+func ValidateSettings(settings Settings) error {
+	return validateSettings(settings)
+}
+
+func getSettings() Settings {
+	settingsLocker.RLock()
+	defer settingsLocker.RUnlock()
+
+	return globalSettings
+}
+
+func validateSettings(settings Settings) error {
+	for _, kind := range settings.ToolOrder {
+		if !isKnownTool(kind) {
+			return fmt.Errorf("unknown diff tool '%s' in the tool order", kind)
+		}
+	}
+
+	if settings.MaxInstances < 0 {
+		return fmt.Errorf("the maximum number of instances can't be negative: %d", settings.MaxInstances)
+	}
+
+	names := make(map[string]bool)
+	for _, tool := range settings.CustomTools {
+		if len(strings.TrimSpace(tool.Name)) == 0 {
+			return fmt.Errorf("custom diff tools require a name")
+		}
+		if names[tool.Name] || isKnownTool(ToolKind(tool.Name)) {
+			return fmt.Errorf("the custom diff tool name is already used: %s", tool.Name)
+		}
+		names[tool.Name] = true
+
+		if len(strings.TrimSpace(tool.ExePath)) == 0 {
+			return fmt.Errorf("the custom diff tool '%s' requires an exe path", tool.Name)
+		}
+
+		arguments := strings.Join(tool.Arguments, " ")
+		if !strings.Contains(arguments, tempFilePlaceholder) || !strings.Contains(arguments, targetFilePlaceholder) {
+			return fmt.Errorf("the arguments of the custom diff tool '%s' must contain %s and %s",
+				tool.Name, tempFilePlaceholder, targetFilePlaceholder)
+		}
+	}
+
+	return nil
+}
+
+func isKnownTool(kind ToolKind) bool {
+	for _, known := range allTools {
+		if known == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CustomTool) buildArguments(tempFile string, targetFile string) []string {
+	arguments := make([]string, len(c.Arguments))
+	for i, argument := range c.Arguments {
+		argument = strings.ReplaceAll(argument, tempFilePlaceholder, tempFile)
+		arguments[i] = strings.ReplaceAll(argument, targetFilePlaceholder, targetFile)
+	}
+	return arguments
+}
+
+// settingsEnvReader reads the environment variables, and falls back to the Settings when a variable is not set
+type settingsEnvReader struct {
+	reader   EnvReader
+	settings Settings
+}
+
+func newEnvReader() EnvReader {
+	return &settingsEnvReader{
+		reader:   &systemEnvReader{},
+		settings: getSettings(),
+	}
+}
+
+func (r *settingsEnvReader) LookupEnv(key string) (string, bool) {
+	if value, found := r.reader.LookupEnv(key); found {
+		return value, true
+	}
+
+	switch key {
+	case envDiffEngineDisabled:
+		if r.settings.Disabled {
+			return "true", true
+		}
+	case envToolOrder:
+		if len(r.settings.ToolOrder) > 0 {
+			order := make([]string, len(r.settings.ToolOrder))
+			for i, kind := range r.settings.ToolOrder {
+				order[i] = string(kind)
+			}
+			return strings.Join(order, ","), true
+		}
+	case envMaxInstances:
+		if r.settings.MaxInstances > 0 {
+			return strconv.Itoa(r.settings.MaxInstances), true
+		}
+	case envTargetOnLeft:
+		if r.settings.TargetOnLeft != nil {
+			return strconv.FormatBool(*r.settings.TargetOnLeft), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestSettingsEnvReader(t *testing.T) {
+	onLeft := true
+	reader := &settingsEnvReader{
+		reader: &TestEnvReader{Key: envMaxInstances, Value: "3"},
+		settings: Settings{
+			ToolOrder:    []ToolKind{Meld, VisualStudioCode},
+			MaxInstances: 10,
+			TargetOnLeft: &onLeft,
+		},
+	}
+
+	if value, _ := reader.LookupEnv(envMaxInstances); value != "3" {
+		t.Fatalf("Should prefer the environment variable, got %s", value)
+	}
+	if value, _ := reader.LookupEnv(envToolOrder); value != "Meld,VisualStudioCode" {
+		t.Fatalf("Should fall back to the settings, got %s", value)
+	}
+	if value, _ := reader.LookupEnv(envTargetOnLeft); value != "true" {
+		t.Fatalf("Should fall back to the settings, got %s", value)
+	}
+	if _, found := reader.LookupEnv(envDiffEngineDisabled); found {
+		t.Fatalf("Should not find the settings that are not configured")
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	table := []struct {
+		name     string
+		settings Settings
+		valid    bool
+	}{
+		{"empty", Settings{}, true},
+		{"known tool", Settings{ToolOrder: []ToolKind{Meld}}, true},
+		{"unknown tool", Settings{ToolOrder: []ToolKind{"Unknown"}}, false},
+		{"negative instances", Settings{MaxInstances: -1}, false},
+		{"custom tool", Settings{CustomTools: []CustomTool{{Name: "custom", ExePath: "/bin/custom", Arguments: []string{"{tempFile}", "{targetFile}"}}}}, true},
+		{"custom tool without placeholders", Settings{CustomTools: []CustomTool{{Name: "custom", ExePath: "/bin/custom"}}}, false},
+		{"custom tool without path", Settings{CustomTools: []CustomTool{{Name: "custom", Arguments: []string{"{tempFile}", "{targetFile}"}}}}, false},
+		{"custom tool with known name", Settings{CustomTools: []CustomTool{{Name: "Meld", ExePath: "/bin/custom", Arguments: []string{"{tempFile}", "{targetFile}"}}}}, false},
+	}
+
+	for _, test := range table {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSettings(test.settings)
+			if (err == nil) != test.valid {
+				t.Fatalf("Unexpected validation result: %v", err)
+			}
+		})
+	}
+}
+
+func TestCustomToolArguments(t *testing.T) {
+	tool := CustomTool{Arguments: []string{"--left={targetFile}", "{tempFile}"}}
+
+	arguments := tool.buildArguments("temp.txt", "target.txt")
+	if arguments[0] != "--left=target.txt" || arguments[1] != "temp.txt" {
+		t.Fatalf("Should replace the placeholders, got %v", arguments)
+	}
+}
+
+func TestUseSettings_TargetPosition(t *testing.T) {
+	if _, found := os.LookupEnv(envTargetOnLeft); found {
+		t.Skip("The position is set by the environment")
+	}
+	defer func() {
+		_ = UseSettings(Settings{})
+	}()
+
+	onLeft := true
+	wait := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wait.Add(2)
+		go func() {
+			defer wait.Done()
+			_ = UseSettings(Settings{TargetOnLeft: &onLeft})
+		}()
+		go func() {
+			defer wait.Done()
+			_ = getTargetPosition()
+		}()
+	}
+	wait.Wait()
+
+	if !getTargetPosition().TargetOnLeft {
+		t.Fatalf("Should use the position of the settings")
+	}
+}
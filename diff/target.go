@@ -10,11 +10,19 @@ type targetPosition struct {
 	TargetOnLeft bool
 }
 
-var position = newTargetPosition()
+// position is guarded by the settingsLocker, since UseSettings replaces it
+var position = newTargetPosition(newEnvReader())
 
-func newTargetPosition() targetPosition {
+func getTargetPosition() targetPosition {
+	settingsLocker.RLock()
+	defer settingsLocker.RUnlock()
+
+	return position
+}
+
+func newTargetPosition(reader EnvReader) targetPosition {
 	pos := targetPosition{}
-	onLeft, found := pos.ReadTargetOnLeft()
+	onLeft, found := pos.ReadTargetOnLeft(reader)
 	if !found {
 		onLeft = false
 	}
@@ -22,8 +30,8 @@ func newTargetPosition() targetPosition {
 	return pos
 }
 
-func (t *targetPosition) ReadTargetOnLeft() (result bool, found bool) {
-	value, ok := os.LookupEnv("DiffEngine_TargetOnLeft")
+func (t *targetPosition) ReadTargetOnLeft(reader EnvReader) (result bool, found bool) {
+	value, ok := reader.LookupEnv(envTargetOnLeft)
 	if !ok {
 		return false, false
 	}
@@ -33,7 +41,7 @@ func (t *targetPosition) ReadTargetOnLeft() (result bool, found bool) {
 	}
 
 	if strings.ToLower(value) == "false" {
-		return true, false
+		return false, true
 	}
 
 	panic(fmt.Sprintf("Unable to parse Position from `DiffEngine_TargetOnLeft`. Must be `true` or `false`. Environment variable: %s", value))
@@ -51,5 +59,5 @@ func (t *targetPosition) SetTargetOnLeft(value bool) {
 		envValue = "true"
 	}
 
-	_ = os.Setenv("DiffEngine_TargetOnLeft", envValue)
+	_ = os.Setenv(envTargetOnLeft, envValue)
 }
@@ -3,7 +3,6 @@ package diff
 import (
 	"fmt"
 	"github.com/VerifyTests/Verify.Go/utils"
-	"os"
 	"strings"
 )
 
@@ -72,6 +71,10 @@ func (t *Tools) initTools(tools []ToolKind, resultFoundInEnvVar bool) {
 	}
 
 	//add custom to the start
+	for _, custom := range getSettings().CustomTools {
+		t.AddTool(custom.Name, custom.AutoRefresh, custom.IsMdi, custom.SupportsText, custom.RequiresTarget,
+			custom.buildArguments, custom.buildArguments, custom.ExePath, custom.BinaryExtensions)
+	}
 }
 
 func (t *Tools) sort(order []ToolKind, throwForNoTool bool) []*ToolDefinition {
@@ -196,7 +199,7 @@ type orderResult struct {
 }
 
 func (t *Tools) readToolOrder() orderResult {
-	diffOrder, found := os.LookupEnv("DiffEngine_ToolOrder")
+	diffOrder, found := newEnvReader().LookupEnv(envToolOrder)
 	var order []ToolKind
 	if found {
 		order = t.parseEnvironment(diffOrder)
@@ -0,0 +1,81 @@
+// Package naming holds the naming rules of the received, verified and diff files,
+// shared by the verifier and the verify command
+package naming
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The kinds of files written for a verified target, named `<prefix>.<kind>.<extension>`
+const (
+	Received = "received"
+	Verified = "verified"
+	Diff     = "diff"
+)
+
+// FilePath returns the path of the `<prefix>.<kind>.<extension>` file
+func FilePath(prefix string, kind string, extension string) string {
+	return prefix + "." + kind + "." + extension
+}
+
+// ParseFilePath returns the prefix and the extension of the `<prefix>.<kind>.<extension>` file path
+func ParseFilePath(filePath string, kind string) (prefix string, extension string, ok bool) {
+	extension = strings.TrimPrefix(filepath.Ext(filePath), ".")
+	if len(extension) == 0 {
+		return "", "", false
+	}
+
+	prefix = strings.TrimSuffix(filePath, "."+kind+"."+extension)
+	if prefix == filePath || len(prefix) == 0 || strings.HasSuffix(filepath.ToSlash(prefix), "/") {
+		return "", "", false
+	}
+	return prefix, extension, true
+}
+
+// FindMatchingFiles returns the files of the kind with the file name prefix, including the indexed targets of the
+// verifications with multiple targets, named `<prefix>.<index>.<kind>.<extension>`
+func FindMatchingFiles(files []string, fileNamePrefix string, kind string) []string {
+	suffix := "." + kind
+	matches := make([]string, 0)
+	for _, f := range files {
+		name := utils.File.GetFileNameWithoutExtension(f)
+		if !strings.HasPrefix(name, fileNamePrefix) {
+			continue
+		}
+
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+
+		prefixRemoved := name[len(fileNamePrefix):]
+		if prefixRemoved == suffix {
+			matches = append(matches, f)
+			continue
+		}
+
+		numberPart := strings.TrimSuffix(prefixRemoved, suffix)
+		if !strings.HasPrefix(numberPart, ".") {
+			continue
+		}
+
+		if _, err := strconv.Atoi(numberPart[1:]); err == nil {
+			matches = append(matches, f)
+		}
+	}
+	return matches
+}
+
+// MirrorPath returns the path under the target root that has the same location as the path under the source root.
+// The paths outside the source root are mirrored with their absolute path, without the volume name.
+func MirrorPath(path string, sourceRoot string, targetRoot string) string {
+	relative := strings.TrimPrefix(path, filepath.VolumeName(path))
+	if len(sourceRoot) > 0 {
+		if rel, err := filepath.Rel(sourceRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			relative = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Join(targetRoot, relative))
+}
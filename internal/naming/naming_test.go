@@ -0,0 +1,41 @@
+package naming
+
+import (
+	"testing"
+)
+
+func TestFindMatchingFiles_SimilarNames(t *testing.T) {
+	files := []string{
+		"dir/test.Name.verified.txt",
+		"dir/test.Name.01.verified.txt",
+		"dir/test.NameLonger.verified.txt",
+		"dir/test.Name.case.verified.txt",
+	}
+
+	matches := FindMatchingFiles(files, "test.Name", Verified)
+	if len(matches) != 2 || matches[0] != files[0] || matches[1] != files[1] {
+		t.Fatalf("Should only match the file and its indexed targets, got %v", matches)
+	}
+}
+
+func TestParseFilePath(t *testing.T) {
+	prefix, extension, ok := ParseFilePath(FilePath("dir/test.Name.01", Received, "txt"), Received)
+	if !ok || prefix != "dir/test.Name.01" || extension != "txt" {
+		t.Fatalf("Should parse the prefix and the extension, got %s and %s", prefix, extension)
+	}
+
+	for _, path := range []string{"dir/test.Name.verified.txt", "dir/.received.txt", "dir/test.received"} {
+		if _, _, ok := ParseFilePath(path, Received); ok {
+			t.Fatalf("Should not parse %s", path)
+		}
+	}
+}
+
+func TestMirrorPath(t *testing.T) {
+	if path := MirrorPath("module/api/tests", "module", "received"); path != "received/api/tests" {
+		t.Fatalf("Should mirror the path under the root, got %s", path)
+	}
+	if path := MirrorPath("/outside/tests", "/module", "/received"); path != "/received/outside/tests" {
+		t.Fatalf("Should mirror the absolute path of the paths outside the root, got %s", path)
+	}
+}
@@ -0,0 +1,121 @@
+// Package pending finds the received files waiting to be accepted or rejected by the verify command
+package pending
+
+import (
+	"github.com/VerifyTests/Verify.Go/internal/naming"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// skippedDirectories directories that never contain snapshots
+var skippedDirectories = map[string]bool{
+	".git":         true,
+	".idea":        true,
+	"vendor":       true,
+	"node_modules": true,
+}
+
+// File a received file waiting to be accepted or rejected, along with its verified file
+type File struct {
+	// Name the file name prefix shared by the received and verified files, e.g. `verify_test.Something`.
+	// The index of the targets of a test that verifies multiple targets is part of the name, e.g. `verify_test.Something.01`.
+	Name string
+	// Extension the extension of the files
+	Extension    string
+	ReceivedPath string
+	VerifiedPath string
+	// DiffPath the diff file written by the comparer, if there is one
+	DiffPath string
+}
+
+// IsNew returns true when there is no verified file yet
+func (f File) IsNew() bool {
+	return !utils.File.Exists(f.VerifiedPath)
+}
+
+// IsText returns true for text files
+func (f File) IsText() bool {
+	return utils.File.IsText(f.Extension)
+}
+
+// Accept replaces the verified file with the received file
+func (f File) Accept() {
+	utils.File.Delete(f.VerifiedPath)
+	utils.File.Move(f.ReceivedPath, f.VerifiedPath)
+	f.deleteDiff()
+}
+
+// Reject deletes the received file and keeps the verified file
+func (f File) Reject() {
+	utils.File.Delete(f.ReceivedPath)
+	f.deleteDiff()
+}
+
+func (f File) deleteDiff() {
+	if len(f.DiffPath) > 0 {
+		utils.File.Delete(f.DiffPath)
+	}
+}
+
+// Find finds all the received files in the root directory and its sub-directories
+func Find(root string) ([]File, error) {
+	return FindMirrored(root, root)
+}
+
+// FindMirrored finds all the received files written to a received directory (see verifier.UseReceivedDirectory),
+// whose verified files are in the mirrored directories of the verified root
+func FindMirrored(receivedRoot, verifiedRoot string) ([]File, error) {
+	root := receivedRoot
+	pending := make([]File, 0)
+	diffFiles := make(map[string]string)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != root && skippedDirectories[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		path = filepath.ToSlash(path)
+		if prefix, extension, ok := naming.ParseFilePath(path, naming.Received); ok {
+			verifiedPrefix := prefix
+			if receivedRoot != verifiedRoot {
+				verifiedPrefix = naming.MirrorPath(prefix, receivedRoot, verifiedRoot)
+			}
+			pending = append(pending, newFile(prefix, verifiedPrefix, extension))
+		} else if prefix, _, ok := naming.ParseFilePath(path, naming.Diff); ok {
+			diffFiles[prefix] = path
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, file := range pending {
+		pending[i].DiffPath = diffFiles[strings.TrimSuffix(file.ReceivedPath, "."+naming.Received+"."+file.Extension)]
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].ReceivedPath < pending[j].ReceivedPath
+	})
+	return pending, nil
+}
+
+// newFile returns the `<prefix>.received.<extension>` file with its verified file. The verified file has the same
+// name, so the indexed targets and the test cases don't need to be told apart.
+func newFile(receivedPrefix, verifiedPrefix, extension string) File {
+	return File{
+		Name:         filepath.Base(receivedPrefix),
+		Extension:    extension,
+		ReceivedPath: naming.FilePath(receivedPrefix, naming.Received, extension),
+		VerifiedPath: naming.FilePath(verifiedPrefix, naming.Verified, extension),
+	}
+}
@@ -0,0 +1,86 @@
+package pending
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	utils.File.WriteText(path.Join(dir, "a_test.Name.received.txt"), "received")
+	utils.File.WriteText(path.Join(dir, "a_test.Name.verified.txt"), "verified")
+	utils.File.WriteText(path.Join(dir, "a_test.Indexed.01.received.png"), "image")
+	utils.File.WriteText(path.Join(dir, "a_test.Indexed.01.diff.png"), "diff")
+	utils.File.WriteText(path.Join(dir, "a_test.Other.verified.txt"), "verified")
+
+	pending, err := Find(dir)
+	if err != nil {
+		t.Fatalf("Should find the files: %s", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("Should find the received files only, got %v", pending)
+	}
+
+	indexed := pending[0]
+	if indexed.Name != "a_test.Indexed.01" || indexed.Extension != "png" {
+		t.Fatalf("Should parse the indexed file name, got %v", indexed)
+	}
+	if indexed.VerifiedPath != path.Join(dir, "a_test.Indexed.01.verified.png") || !indexed.IsNew() {
+		t.Fatalf("Should find the verified file, got %s", indexed.VerifiedPath)
+	}
+	if indexed.DiffPath != path.Join(dir, "a_test.Indexed.01.diff.png") {
+		t.Fatalf("Should find the diff file, got %s", indexed.DiffPath)
+	}
+
+	named := pending[1]
+	if named.Name != "a_test.Name" || named.IsNew() || !named.IsText() {
+		t.Fatalf("Should parse the file name, got %v", named)
+	}
+}
+
+func TestPendingFile_AcceptAndReject(t *testing.T) {
+	dir := t.TempDir()
+	utils.File.WriteText(path.Join(dir, "a_test.Accept.received.txt"), "received")
+	utils.File.WriteText(path.Join(dir, "a_test.Accept.verified.txt"), "verified")
+	utils.File.WriteText(path.Join(dir, "a_test.Reject.received.txt"), "received")
+
+	pending, _ := Find(dir)
+	pending[0].Accept()
+	pending[1].Reject()
+
+	if string(utils.File.ReadFile(path.Join(dir, "a_test.Accept.verified.txt"))) != "received" {
+		t.Fatalf("Should replace the verified file")
+	}
+	if remaining, _ := Find(dir); len(remaining) != 0 {
+		t.Fatalf("Should not leave received files, got %v", remaining)
+	}
+}
+
+func TestFindMirrored(t *testing.T) {
+	verifiedRoot := t.TempDir()
+	receivedRoot := t.TempDir()
+	_ = utils.File.CreateDirectory(path.Join(receivedRoot, "pkg"))
+	utils.File.WriteText(path.Join(receivedRoot, "pkg", "a_test.Name.received.txt"), "received")
+
+	pending, err := FindMirrored(receivedRoot, verifiedRoot)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("Should find the received file, got %v %v", pending, err)
+	}
+	if pending[0].VerifiedPath != path.Join(verifiedRoot, "pkg", "a_test.Name.verified.txt") {
+		t.Fatalf("Should map the verified file to the verified root, got %s", pending[0].VerifiedPath)
+	}
+}
+
+func TestFindPendingFiles_NumericTestCase(t *testing.T) {
+	dir := t.TempDir()
+	utils.File.WriteText(path.Join(dir, "a_test.Table.1.received.txt"), "received")
+
+	pending, _ := Find(dir)
+	if len(pending) != 1 || pending[0].Name != "a_test.Table.1" {
+		t.Fatalf("Should keep the test case in the name, got %v", pending)
+	}
+	if pending[0].VerifiedPath != path.Join(dir, "a_test.Table.1.verified.txt") {
+		t.Fatalf("Should find the verified file, got %s", pending[0].VerifiedPath)
+	}
+}
@@ -0,0 +1,439 @@
+// Package textdiff creates the unified diffs of the text files shown by the verifier and the verify command
+package textdiff
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultContextLines the default number of unchanged lines shown around each change
+	DefaultContextLines = 3
+	// DefaultMaxHunkLines the default maximum number of lines shown for each hunk
+	DefaultMaxHunkLines = 100
+)
+
+// maxDiffDistance limits the number of edits the diff algorithm searches for, to keep the
+// failure message fast for completely different files
+const maxDiffDistance = 2000
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiCyan    = "\x1b[36m"
+	ansiReverse = "\x1b[7m"
+	ansiNormal  = "\x1b[27m"
+)
+
+type diffOperation byte
+
+const (
+	diffEqual  diffOperation = ' '
+	diffDelete diffOperation = '-'
+	diffInsert diffOperation = '+'
+)
+
+type diffLine struct {
+	operation diffOperation
+	text      string
+	// oldLine and newLine are the zero based line numbers in the verified and received texts
+	oldLine int
+	newLine int
+}
+
+// Options options of the unified diff
+type Options struct {
+	// ContextLines the number of unchanged lines shown around each change
+	ContextLines int
+	// WordDiff highlights the changed words within the changed lines
+	WordDiff bool
+	// UseColor uses ANSI colors
+	UseColor bool
+	// MaxHunkLines the maximum number of lines shown for each hunk. Zero shows all the lines.
+	MaxHunkLines int
+}
+
+type differ struct {
+	options Options
+}
+
+// Unified creates a unified diff of the verified and received texts
+func Unified(verifiedName, receivedName, verified, received string, options Options) string {
+	d := &differ{options: options}
+	lines := diffTextLines(strings.Split(verified, "\n"), strings.Split(received, "\n"))
+
+	builder := strings.Builder{}
+	builder.WriteString(d.colorize(ansiRed, fmt.Sprintf("--- %s", verifiedName)))
+	builder.WriteRune('\n')
+	builder.WriteString(d.colorize(ansiGreen, fmt.Sprintf("+++ %s", receivedName)))
+	builder.WriteRune('\n')
+
+	for _, hunk := range d.hunks(lines) {
+		d.writeHunk(&builder, hunk)
+	}
+
+	return builder.String()
+}
+
+// IsColorTerminal checks if the standard output is a terminal that supports colors
+func IsColorTerminal() bool {
+	if _, found := os.LookupEnv("NO_COLOR"); found {
+		return false
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (d *differ) hunks(lines []diffLine) [][]diffLine {
+	context := d.options.ContextLines
+	if context < 0 {
+		context = 0
+	}
+
+	hunks := make([][]diffLine, 0)
+	start, end := -1, -1
+	for i, line := range lines {
+		if line.operation == diffEqual {
+			continue
+		}
+
+		from := maxInt(i-context, 0)
+		to := minInt(i+context, len(lines)-1)
+		if start != -1 && from > end+1 {
+			hunks = append(hunks, lines[start:end+1])
+			start = -1
+		}
+		if start == -1 {
+			start = from
+		}
+		end = to
+	}
+
+	if start != -1 {
+		hunks = append(hunks, lines[start:end+1])
+	}
+	return hunks
+}
+
+func (d *differ) writeHunk(builder *strings.Builder, hunk []diffLine) {
+	oldStart, oldCount, newStart, newCount := -1, 0, -1, 0
+	for _, line := range hunk {
+		if line.operation != diffInsert {
+			if oldStart == -1 {
+				oldStart = line.oldLine
+			}
+			oldCount++
+		}
+		if line.operation != diffDelete {
+			if newStart == -1 {
+				newStart = line.newLine
+			}
+			newCount++
+		}
+	}
+
+	header := fmt.Sprintf("@@ -%s +%s @@", hunkRange(oldStart, oldCount, hunk[0].oldLine),
+		hunkRange(newStart, newCount, hunk[0].newLine))
+	builder.WriteString(d.colorize(ansiCyan, header))
+	builder.WriteRune('\n')
+
+	rendered := d.renderLines(hunk)
+	shown := len(rendered)
+	if d.options.MaxHunkLines > 0 && shown > d.options.MaxHunkLines {
+		shown = d.options.MaxHunkLines
+	}
+
+	for _, line := range rendered[:shown] {
+		builder.WriteString(line)
+		builder.WriteRune('\n')
+	}
+
+	if shown < len(rendered) {
+		builder.WriteString(fmt.Sprintf("... %d more lines\n", len(rendered)-shown))
+	}
+}
+
+func hunkRange(start, count, fallback int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", fallback)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func (d *differ) renderLines(hunk []diffLine) []string {
+	rendered := make([]string, 0, len(hunk))
+	for i := 0; i < len(hunk); {
+		if hunk[i].operation == diffEqual {
+			rendered = append(rendered, " "+hunk[i].text)
+			i++
+			continue
+		}
+
+		deleted := make([]string, 0)
+		for ; i < len(hunk) && hunk[i].operation == diffDelete; i++ {
+			deleted = append(deleted, hunk[i].text)
+		}
+		inserted := make([]string, 0)
+		for ; i < len(hunk) && hunk[i].operation == diffInsert; i++ {
+			inserted = append(inserted, hunk[i].text)
+		}
+
+		rendered = append(rendered, d.renderChange(deleted, inserted)...)
+	}
+	return rendered
+}
+
+func (d *differ) renderChange(deleted, inserted []string) []string {
+	deletedLines := make([]string, len(deleted))
+	insertedLines := make([]string, len(inserted))
+	copy(deletedLines, deleted)
+	copy(insertedLines, inserted)
+
+	if d.options.WordDiff {
+		for i := 0; i < len(deleted) && i < len(inserted); i++ {
+			deletedLines[i], insertedLines[i] = d.highlightWords(deleted[i], inserted[i])
+		}
+	}
+
+	result := make([]string, 0, len(deleted)+len(inserted))
+	for _, line := range deletedLines {
+		result = append(result, d.colorize(ansiRed, "-"+line))
+	}
+	for _, line := range insertedLines {
+		result = append(result, d.colorize(ansiGreen, "+"+line))
+	}
+	return result
+}
+
+func (d *differ) highlightWords(deleted, inserted string) (string, string) {
+	words := diffTextLines(splitWords(deleted), splitWords(inserted))
+
+	deletedBuilder := strings.Builder{}
+	insertedBuilder := strings.Builder{}
+	for i := 0; i < len(words); {
+		operation := words[i].operation
+		run := strings.Builder{}
+		for ; i < len(words) && words[i].operation == operation; i++ {
+			run.WriteString(words[i].text)
+		}
+
+		switch operation {
+		case diffEqual:
+			deletedBuilder.WriteString(run.String())
+			insertedBuilder.WriteString(run.String())
+		case diffDelete:
+			deletedBuilder.WriteString(d.highlight("[-", run.String(), "-]"))
+		case diffInsert:
+			insertedBuilder.WriteString(d.highlight("{+", run.String(), "+}"))
+		}
+	}
+	return deletedBuilder.String(), insertedBuilder.String()
+}
+
+func (d *differ) highlight(open, text, close string) string {
+	if d.options.UseColor {
+		return ansiReverse + text + ansiNormal
+	}
+	return open + text + close
+}
+
+func (d *differ) colorize(color, text string) string {
+	if d.options.UseColor {
+		return color + text + ansiReset
+	}
+	return text
+}
+
+// splitWords splits the line into runs of letters and digits, runs of spaces and single punctuation characters
+func splitWords(line string) []string {
+	words := make([]string, 0)
+	current := strings.Builder{}
+	kind := -1
+
+	classify := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 0
+		case unicode.IsSpace(r):
+			return 1
+		}
+		return 2
+	}
+
+	for _, r := range line {
+		runeKind := classify(r)
+		if current.Len() > 0 && (runeKind != kind || runeKind == 2) {
+			words = append(words, current.String())
+			current.Reset()
+		}
+		kind = runeKind
+		current.WriteRune(r)
+	}
+
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+	return words
+}
+
+// diffTextLines finds the shortest edit script between the old and new lines using the Myers algorithm
+func diffTextLines(oldLines, newLines []string) []diffLine {
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]diffLine, 0, len(oldLines)+len(newLines))
+	for i := 0; i < prefix; i++ {
+		result = append(result, diffLine{operation: diffEqual, text: oldLines[i], oldLine: i, newLine: i})
+	}
+
+	oldMiddle := oldLines[prefix : len(oldLines)-suffix]
+	newMiddle := newLines[prefix : len(newLines)-suffix]
+	for _, line := range myersDiff(oldMiddle, newMiddle) {
+		line.oldLine += prefix
+		line.newLine += prefix
+		result = append(result, line)
+	}
+
+	for i := 0; i < suffix; i++ {
+		oldIndex := len(oldLines) - suffix + i
+		newIndex := len(newLines) - suffix + i
+		result = append(result, diffLine{operation: diffEqual, text: oldLines[oldIndex], oldLine: oldIndex, newLine: newIndex})
+	}
+	return result
+}
+
+func myersDiff(oldLines, newLines []string) []diffLine {
+	n, m := len(oldLines), len(newLines)
+	max := n + m
+	if max == 0 {
+		return nil
+	}
+
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	trace := make([][]int, 0)
+
+	found := false
+	for d := 0; d <= max && d <= maxDiffDistance; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && oldLines[x] == newLines[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+
+		window := make([]int, 2*d+1)
+		copy(window, v[offset-d:offset+d+1])
+		trace = append(trace, window)
+
+		if found {
+			break
+		}
+	}
+
+	if !found {
+		return replaceAll(oldLines, newLines)
+	}
+
+	reversed := make([]diffLine, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		previous := trace[d-1]
+		at := func(k int) int { return previous[k+d-1] }
+
+		k := x - y
+		var previousK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			previousK = k + 1
+		} else {
+			previousK = k - 1
+		}
+		previousX := at(previousK)
+		previousY := previousX - previousK
+
+		for x > previousX && y > previousY {
+			x--
+			y--
+			reversed = append(reversed, diffLine{operation: diffEqual, text: oldLines[x], oldLine: x, newLine: y})
+		}
+
+		if x == previousX {
+			y--
+			reversed = append(reversed, diffLine{operation: diffInsert, text: newLines[y], oldLine: x, newLine: y})
+		} else {
+			x--
+			reversed = append(reversed, diffLine{operation: diffDelete, text: oldLines[x], oldLine: x, newLine: y})
+		}
+	}
+
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, diffLine{operation: diffEqual, text: oldLines[x], oldLine: x, newLine: y})
+	}
+
+	result := make([]diffLine, len(reversed))
+	for i, line := range reversed {
+		result[len(reversed)-1-i] = line
+	}
+	return result
+}
+
+func replaceAll(oldLines, newLines []string) []diffLine {
+	result := make([]diffLine, 0, len(oldLines)+len(newLines))
+	for i, line := range oldLines {
+		result = append(result, diffLine{operation: diffDelete, text: line, oldLine: i, newLine: 0})
+	}
+	for i, line := range newLines {
+		result = append(result, diffLine{operation: diffInsert, text: line, oldLine: len(oldLines), newLine: i})
+	}
+	return result
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
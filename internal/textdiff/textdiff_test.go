@@ -0,0 +1,108 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func defaultOptions() Options {
+	return Options{
+		ContextLines: DefaultContextLines,
+		MaxHunkLines: DefaultMaxHunkLines,
+	}
+}
+
+func TestTextDiff_Unified(t *testing.T) {
+	verified := "line1\nline2\nline3\nline4\nline5\nline6\nline7\nline8\nline9\nline10"
+	received := "line1\nline2\nline3\nline4\nchanged5\nline6\nline7\nline8\nline9\nline10\nline11"
+
+	options := defaultOptions()
+	options.ContextLines = 2
+	result := Unified("a.verified.txt", "a.received.txt", verified, received, options)
+
+	expected := `--- a.verified.txt
++++ a.received.txt
+@@ -3,5 +3,5 @@
+ line3
+ line4
+-line5
++changed5
+ line6
+ line7
+@@ -9,2 +9,3 @@
+ line9
+ line10
++line11
+`
+	if result != expected {
+		t.Fatalf("unexpected diff:\n%s", result)
+	}
+}
+
+func TestTextDiff_MergesCloseHunks(t *testing.T) {
+	verified := "a\nb\nc\nd\ne"
+	received := "A\nb\nc\nD\ne"
+
+	result := Unified("verified", "received", verified, received, defaultOptions())
+	if strings.Count(result, "@@ -") != 1 {
+		t.Fatalf("changes within the context should be in one hunk:\n%s", result)
+	}
+	if !strings.Contains(result, "@@ -1,5 +1,5 @@") {
+		t.Fatalf("unexpected hunk header:\n%s", result)
+	}
+}
+
+func TestTextDiff_WordDiff(t *testing.T) {
+	options := defaultOptions()
+	options.WordDiff = true
+
+	result := Unified("verified", "received", "the quick brown fox", "the slow brown fox", options)
+	if !strings.Contains(result, "-the [-quick-] brown fox") {
+		t.Fatalf("removed words should be highlighted:\n%s", result)
+	}
+	if !strings.Contains(result, "+the {+slow+} brown fox") {
+		t.Fatalf("added words should be highlighted:\n%s", result)
+	}
+}
+
+func TestTextDiff_Color(t *testing.T) {
+	options := defaultOptions()
+	options.UseColor = true
+
+	result := Unified("verified", "received", "old", "new", options)
+	if !strings.Contains(result, ansiRed+"-old"+ansiReset) {
+		t.Fatalf("removed lines should be red:\n%q", result)
+	}
+	if !strings.Contains(result, ansiGreen+"+new"+ansiReset) {
+		t.Fatalf("added lines should be green:\n%q", result)
+	}
+}
+
+func TestTextDiff_TruncatesHunks(t *testing.T) {
+	verified := strings.Builder{}
+	received := strings.Builder{}
+	for i := 0; i < 50; i++ {
+		verified.WriteString(fmt.Sprintf("verified %d\n", i))
+		received.WriteString(fmt.Sprintf("received %d\n", i))
+	}
+
+	options := defaultOptions()
+	options.MaxHunkLines = 10
+	result := Unified("verified", "received", verified.String(), received.String(), options)
+
+	if !strings.Contains(result, "... 91 more lines") {
+		t.Fatalf("hunk should have been truncated:\n%s", result)
+	}
+}
+
+func TestTextDiff_Insertions(t *testing.T) {
+	lines := diffTextLines([]string{"a", "c"}, []string{"a", "b", "c"})
+	operations := ""
+	for _, line := range lines {
+		operations += string(line.operation)
+	}
+	if operations != " + " {
+		t.Fatalf("unexpected operations: %q", operations)
+	}
+}
@@ -63,6 +63,30 @@ func (f *Files) GetCurrentDirectory() string {
 	return wd
 }
 
+// FindInParents searches the directory and its parent directories for a file with one of the provided names,
+// and returns the full path of the first match. The names are checked in order in every directory.
+func (f *Files) FindInParents(directory string, fileNames ...string) (string, bool) {
+	current, err := filepath.Abs(directory)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		for _, fileName := range fileNames {
+			candidate := filepath.Join(current, fileName)
+			if f.Exists(candidate) {
+				return candidate, true
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+		current = parent
+	}
+}
+
 // GetFileNameWithoutExtension returns the name of the file without its extension.
 func (f *Files) GetFileNameWithoutExtension(fileName string) string {
 	return strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
@@ -213,10 +237,10 @@ func (f *Files) FileOrDirectoryExists(path string) (bool, error) {
 	return false, err
 }
 
-// CreateDirectory creates a directory
+// CreateDirectory creates a directory, along with any missing parents
 func (f *Files) CreateDirectory(directory string) error {
 	if !f.Exists(directory) {
-		return os.Mkdir(directory, os.ModePerm)
+		return os.MkdirAll(directory, os.ModePerm)
 	}
 	return nil
 }
@@ -22,3 +22,17 @@ func TestFileReading(t *testing.T) {
 		t.Fatalf("should read file content")
 	}
 }
+
+func TestFindInParents(t *testing.T) {
+	found, ok := File.FindInParents("../_testdata/DirForSearch/dir1", "go.mod")
+	if !ok {
+		t.Fatalf("should find the file in a parent directory")
+	}
+	if File.GetFullPath("../go.mod") != found {
+		t.Fatalf("should return the full path of the file: %s", found)
+	}
+
+	if _, ok := File.FindInParents(".", "missing-file.unknown"); ok {
+		t.Fatalf("should not find a missing file")
+	}
+}
@@ -31,6 +31,13 @@ func (g *Guards) AgainstEmpty(value string) {
 	}
 }
 
+// AgainstNil guards against the value being nil
+func (g *Guards) AgainstNil(value interface{}) {
+	if value == nil {
+		panic("value was expected but was nil")
+	}
+}
+
 // AgainstBadExtension guards agaist having a "." in the provided file extension.
 func (g *Guards) AgainstBadExtension(value string) {
 	if strings.HasPrefix(value, ".") {
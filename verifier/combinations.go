@@ -0,0 +1,181 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// VerifyCombinations calls the function with every combination of the inputs and verifies the results
+// with the default settings. See Verifier.VerifyCombinations.
+func VerifyCombinations(t testingT, fun interface{}, inputs ...interface{}) {
+	newVerifier(t, nil).VerifyCombinations(fun, inputs...)
+}
+
+// VerifyCombinations calls the function with every combination of the inputs and verifies the results, one line
+// per combination. Each input is a slice or an array with the values of the matching function parameter.
+// The function can return a value, an error, or a value and an error. Returned errors and panics are written
+// as the result of the combination, and the values are serialized like any other target.
+func (v *verifier) VerifyCombinations(fun interface{}, inputs ...interface{}) {
+	function, values := validateCombinations(fun, inputs)
+	serializer := newSerializer(v.settings, v.settings.scrubber)
+
+	rows := make([][]string, 0)
+	results := make([]string, 0)
+	forEachCombination(values, func(combination []reflect.Value) {
+		row := make([]string, 0, len(combination))
+		for _, value := range combination {
+			row = append(row, serializeCombinationValue(serializer, value.Interface()))
+		}
+		rows = append(rows, row)
+		results = append(results, runCombination(serializer, function, combination))
+	})
+
+	v.Verify(formatCombinations(rows, results))
+}
+
+func validateCombinations(fun interface{}, inputs []interface{}) (reflect.Value, [][]reflect.Value) {
+	function := reflect.ValueOf(fun)
+	if function.Kind() != reflect.Func || function.IsNil() {
+		panic(fmt.Sprintf("VerifyCombinations expects a function, but got %T", fun))
+	}
+
+	functionType := function.Type()
+	if functionType.IsVariadic() || functionType.NumIn() != len(inputs) {
+		panic(fmt.Sprintf("The function expects %d parameters, but %d inputs were provided", functionType.NumIn(), len(inputs)))
+	}
+	if len(inputs) == 0 {
+		panic("VerifyCombinations expects at least one input")
+	}
+
+	values := make([][]reflect.Value, 0, len(inputs))
+	for i, input := range inputs {
+		inputValue := reflect.ValueOf(input)
+		if inputValue.Kind() != reflect.Slice && inputValue.Kind() != reflect.Array {
+			panic(fmt.Sprintf("Input %d should be a slice or an array, but got %T", i+1, input))
+		}
+
+		parameterType := functionType.In(i)
+		if !inputValue.Type().Elem().AssignableTo(parameterType) {
+			panic(fmt.Sprintf("Input %d of %s can't be passed as %s", i+1, inputValue.Type(), parameterType))
+		}
+
+		elements := make([]reflect.Value, 0, inputValue.Len())
+		for j := 0; j < inputValue.Len(); j++ {
+			elements = append(elements, inputValue.Index(j))
+		}
+		values = append(values, elements)
+	}
+
+	return function, values
+}
+
+// forEachCombination calls the callback for the cartesian product of the values, varying the last input first
+func forEachCombination(values [][]reflect.Value, callback func(combination []reflect.Value)) {
+	for _, elements := range values {
+		if len(elements) == 0 {
+			return
+		}
+	}
+
+	indexes := make([]int, len(values))
+	for {
+		combination := make([]reflect.Value, len(values))
+		for i, index := range indexes {
+			combination[i] = values[i][index]
+		}
+		callback(combination)
+
+		position := len(indexes) - 1
+		for ; position >= 0; position-- {
+			indexes[position]++
+			if indexes[position] < len(values[position]) {
+				break
+			}
+			indexes[position] = 0
+		}
+		if position < 0 {
+			return
+		}
+	}
+}
+
+func runCombination(serializer *serializer, function reflect.Value, combination []reflect.Value) string {
+	var returned []reflect.Value
+	recovered, panicked := capturePanic(func() {
+		returned = function.Call(combination)
+	})
+	if panicked {
+		return fmt.Sprintf("panic: %v", recovered)
+	}
+
+	if len(returned) == 0 {
+		return "done"
+	}
+
+	last := returned[len(returned)-1]
+	if last.Type().Implements(errorType) && !isNil(last.Interface()) {
+		return fmt.Sprintf("error: %s", last.Interface().(error).Error())
+	}
+
+	if len(returned) == 1 && last.Type() == errorType {
+		return "done"
+	}
+
+	return serializeCombinationValue(serializer, returned[0].Interface())
+}
+
+// serializeCombinationValue serializes the value on a single line
+func serializeCombinationValue(serializer *serializer, value interface{}) string {
+	if isNil(value) {
+		return "nil"
+	}
+
+	serialized := serializer.Serialize(value)
+	compacted := bytes.Buffer{}
+	if err := json.Compact(&compacted, []byte(serialized)); err == nil {
+		return compacted.String()
+	}
+	return strings.ReplaceAll(serialized, "\n", " ")
+}
+
+// formatCombinations writes one line per combination, with the input columns aligned
+func formatCombinations(rows [][]string, results []string) string {
+	if len(rows) == 0 {
+		return "no combinations"
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, column := range row {
+			if width := utf8.RuneCountInString(column); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	builder := strings.Builder{}
+	for i, row := range rows {
+		builder.WriteString("[")
+		for j, column := range row {
+			builder.WriteString(column)
+			if j < len(row)-1 {
+				builder.WriteString(",")
+			} else {
+				builder.WriteString("]")
+			}
+			builder.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(column)+1))
+		}
+		builder.WriteString("=> ")
+		builder.WriteString(results[i])
+		if i < len(rows)-1 {
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
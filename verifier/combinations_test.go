@@ -0,0 +1,72 @@
+package verifier
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestForEachCombination(t *testing.T) {
+	_, values := validateCombinations(func(a int, b string) {}, []interface{}{[]int{1, 2}, []string{"a", "b", "c"}})
+
+	combinations := make([]string, 0)
+	forEachCombination(values, func(combination []reflect.Value) {
+		combinations = append(combinations, formatValues(combination))
+	})
+
+	expected := "1a 1b 1c 2a 2b 2c"
+	if strings.Join(combinations, " ") != expected {
+		t.Fatalf("Should vary the last input first: %v", combinations)
+	}
+}
+
+func TestForEachCombination_EmptyInput(t *testing.T) {
+	_, values := validateCombinations(func(a int, b string) {}, []interface{}{[]int{1, 2}, []string{}})
+
+	forEachCombination(values, func(combination []reflect.Value) {
+		t.Fatalf("Should not call the callback when an input is empty")
+	})
+}
+
+func TestValidateCombinations(t *testing.T) {
+	cases := map[string]struct {
+		fun    interface{}
+		inputs []interface{}
+	}{
+		"not a function":      {fun: 1, inputs: []interface{}{[]int{1}}},
+		"parameter count":     {fun: func(a int) {}, inputs: []interface{}{[]int{1}, []int{2}}},
+		"not a slice":         {fun: func(a int) {}, inputs: []interface{}{1}},
+		"not assignable":      {fun: func(a int) {}, inputs: []interface{}{[]string{"a"}}},
+		"no inputs":           {fun: func() {}, inputs: []interface{}{}},
+		"variadic parameters": {fun: func(a ...int) {}, inputs: []interface{}{[]int{1}}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Should panic")
+				}
+			}()
+			validateCombinations(c.fun, c.inputs)
+		})
+	}
+}
+
+func TestFormatCombinations(t *testing.T) {
+	result := formatCombinations([][]string{{"1", `"a"`}, {"100", `"bb"`}}, []string{"x", "y"})
+
+	expected := "[1,   \"a\"]  => x\n[100, \"bb\"] => y"
+	if result != expected {
+		t.Fatalf("Should align the inputs:\n%s", result)
+	}
+}
+
+func formatValues(values []reflect.Value) string {
+	builder := strings.Builder{}
+	for _, value := range values {
+		builder.WriteString(fmt.Sprint(value.Interface()))
+	}
+	return builder.String()
+}
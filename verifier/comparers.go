@@ -11,6 +11,72 @@ type compare struct {
 
 var comparer = compare{}
 
+// RegisterStringComparer registers a package-level function for string comparison of files with the extension.
+// Comparers configured on a verifier take precedence. Passing nil removes the registered comparer.
+func RegisterStringComparer(extension string, fun StringComparerFunc) {
+	utils.Guard.AgainstBadExtension(extension)
+
+	updateDefaults(func(d *defaults) {
+		if fun == nil {
+			delete(d.stringComparers, extension)
+			return
+		}
+		d.stringComparers[extension] = fun
+	})
+}
+
+// SetDefaultStringComparer sets the package-level function for string comparison of all the extensions
+// without a registered comparer. Passing nil restores the exact string comparison.
+func SetDefaultStringComparer(fun StringComparerFunc) {
+	updateDefaults(func(d *defaults) {
+		d.defaultStringComparer = fun
+	})
+}
+
+func tryGetGlobalStringComparer(extension string) (comp StringComparerFunc, ok bool) {
+	readDefaults(func(d *defaults) {
+		if comp, ok = d.stringComparers[extension]; ok {
+			return
+		}
+		comp = d.defaultStringComparer
+		ok = comp != nil
+	})
+	return
+}
+
+// RegisterStreamComparer registers a package-level function for stream comparison of files with the extension.
+// Comparers configured on a verifier take precedence. Passing nil removes the registered comparer.
+func RegisterStreamComparer(extension string, fun StreamComparerFunc) {
+	utils.Guard.AgainstBadExtension(extension)
+
+	updateDefaults(func(d *defaults) {
+		if fun == nil {
+			delete(d.streamComparers, extension)
+			return
+		}
+		d.streamComparers[extension] = fun
+	})
+}
+
+// SetDefaultStreamComparer sets the package-level function for stream comparison of all the extensions
+// without a registered comparer. Passing nil restores the exact binary comparison.
+func SetDefaultStreamComparer(fun StreamComparerFunc) {
+	updateDefaults(func(d *defaults) {
+		d.defaultStreamComparer = fun
+	})
+}
+
+func tryGetGlobalStreamComparer(extension string) (comp StreamComparerFunc, ok bool) {
+	readDefaults(func(d *defaults) {
+		if comp, ok = d.streamComparers[extension]; ok {
+			return
+		}
+		comp = d.defaultStreamComparer
+		ok = comp != nil
+	})
+	return
+}
+
 func (c *compare) Text(filePair FilePair, received string, settings *verifySettings) EqualityResult {
 	utils.File.DeleteIfEmpty(filePair.VerifiedPath)
 	if !utils.File.Exists(filePair.VerifiedPath) {
@@ -31,6 +97,7 @@ func (c *compare) Text(filePair FilePair, received string, settings *verifySetti
 	}
 
 	utils.File.WriteText(filePair.ReceivedPath, received)
+	writeDiff(filePair, result)
 	return EqualityResult{
 		Equality: FileNotEqual,
 		Message:  result.Message,
@@ -38,16 +105,15 @@ func (c *compare) Text(filePair FilePair, received string, settings *verifySetti
 }
 
 func compareStrings(extension string, received string, verified string, settings *verifySettings) CompareResult {
-	isEqual := verified == received
+	if verified == received {
+		return CompareResult{IsEqual: true}
+	}
 
-	if !isEqual {
-		comparer, ok := settings.tryGetStringComparer(extension)
-		if ok {
-			comparer(received, verified)
-		}
+	if comparer, ok := settings.tryGetStringComparer(extension); ok {
+		return comparer(received, verified)
 	}
 
-	return CompareResult{IsEqual: isEqual}
+	return CompareResult{IsEqual: false}
 }
 
 func (c *compare) Streams(filePair FilePair, receivedStream []byte, settings *verifySettings) EqualityResult {
@@ -66,22 +132,37 @@ func (c *compare) Streams(filePair FilePair, receivedStream []byte, settings *ve
 		}
 	}
 
-	if utils.File.GetLength(filePair.VerifiedPath) != int64(len(receivedStream)) {
-		utils.File.WriteStream(filePair.ReceivedPath, receivedStream)
-		return EqualityResult{
-			Equality: FileNotEqual,
-		}
-	}
-
 	verifiedStream := utils.File.ReadFile(filePair.VerifiedPath)
-	if !bytes.Equal(verifiedStream, receivedStream) {
-		utils.File.WriteStream(filePair.ReceivedPath, receivedStream)
+	result := compareStreams(filePair.Extension, receivedStream, verifiedStream, settings)
+	if result.IsEqual {
 		return EqualityResult{
-			Equality: FileNotEqual,
+			Equality: FileEqual,
 		}
 	}
 
+	utils.File.WriteStream(filePair.ReceivedPath, receivedStream)
+	writeDiff(filePair, result)
 	return EqualityResult{
-		Equality: FileEqual,
+		Equality: FileNotEqual,
+		Message:  result.Message,
+	}
+}
+
+func writeDiff(filePair FilePair, result CompareResult) {
+	if len(result.Diff) == 0 || len(result.DiffExtension) == 0 {
+		return
+	}
+	utils.File.WriteStream(getDiffPath(filePair, result.DiffExtension), result.Diff)
+}
+
+func compareStreams(extension string, received []byte, verified []byte, settings *verifySettings) CompareResult {
+	if bytes.Equal(verified, received) {
+		return CompareResult{IsEqual: true}
 	}
+
+	if comparer, ok := settings.tryGetStreamComparer(extension); ok {
+		return comparer(received, verified)
+	}
+
+	return CompareResult{IsEqual: false}
 }
@@ -0,0 +1,152 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path"
+	"strings"
+	"testing"
+)
+
+func tolerantComparer(received, verified string) CompareResult {
+	isEqual := strings.EqualFold(strings.TrimSpace(received), strings.TrimSpace(verified))
+	if isEqual {
+		return CompareResult{IsEqual: true}
+	}
+	return CompareResult{IsEqual: false, Message: "content differs ignoring case"}
+}
+
+func TestCompareStrings_UsesExtensionComparer(t *testing.T) {
+	s := newSettings(t)
+	UseStringComparerForExtension("sql", tolerantComparer)(s)
+
+	result := compareStrings("sql", "SELECT * FROM T", "select * from t\n", s)
+	if !result.IsEqual {
+		t.Fatalf("comparer for the extension should have decided the equality")
+	}
+
+	result = compareStrings("txt", "SELECT * FROM T", "select * from t\n", s)
+	if result.IsEqual {
+		t.Fatalf("comparer should only be used for the registered extension")
+	}
+}
+
+func TestCompareStrings_UsesDefaultComparer(t *testing.T) {
+	s := newSettings(t)
+	UseStringComparer(tolerantComparer)(s)
+
+	result := compareStrings("html", "<B>", "<b>", s)
+	if !result.IsEqual {
+		t.Fatalf("default comparer should have decided the equality")
+	}
+
+	result = compareStrings("html", "<b>", "<i>", s)
+	if result.IsEqual {
+		t.Fatalf("should not be equal")
+	}
+	if result.Message != "content differs ignoring case" {
+		t.Fatalf("message of the comparer was not returned: %s", result.Message)
+	}
+}
+
+func TestCompareStrings_UsesGlobalComparer(t *testing.T) {
+	RegisterStringComparer("sql", tolerantComparer)
+	t.Cleanup(ResetDefaults)
+
+	s := newSettings(t)
+	result := compareStrings("sql", "SELECT 1", "select 1", s)
+	if !result.IsEqual {
+		t.Fatalf("package-level comparer should have decided the equality")
+	}
+
+	UseStringComparerForExtension("sql", func(received, verified string) CompareResult {
+		return CompareResult{IsEqual: false, Message: "verifier comparer"}
+	})(s)
+
+	result = compareStrings("sql", "SELECT 1", "select 1", s)
+	if result.IsEqual || result.Message != "verifier comparer" {
+		t.Fatalf("comparer of the verifier should take precedence")
+	}
+}
+
+func TestCompareText_ReportsComparerMessage(t *testing.T) {
+	dir := t.TempDir()
+	file := newFilePair("txt", path.Join(dir, "comparer"))
+	utils.File.WriteText(file.VerifiedPath, "expected")
+
+	s := newSettings(t)
+	UseStringComparer(func(received, verified string) CompareResult {
+		return CompareResult{IsEqual: false, Message: "custom mismatch"}
+	})(s)
+
+	result := comparer.Text(file, "actual", s)
+	if result.Equality != FileNotEqual {
+		t.Fatalf("should not be equal")
+	}
+	if result.Message != "custom mismatch" {
+		t.Fatalf("message of the comparer was not returned: %s", result.Message)
+	}
+	if !utils.File.Exists(file.ReceivedPath) {
+		t.Fatalf("received file should have been written")
+	}
+}
+
+func headerInsensitiveComparer(received, verified []byte) CompareResult {
+	if len(received) < 4 || len(verified) < 4 {
+		return CompareResult{IsEqual: false, Message: "stream is too short"}
+	}
+	if string(received[4:]) == string(verified[4:]) {
+		return CompareResult{IsEqual: true}
+	}
+	return CompareResult{IsEqual: false, Message: "payload differs"}
+}
+
+func TestCompareStreams_UsesExtensionComparer(t *testing.T) {
+	s := newSettings(t)
+	UseStreamComparerForExtension("pdf", headerInsensitiveComparer)(s)
+
+	result := compareStreams("pdf", []byte("0001data"), []byte("0002data"), s)
+	if !result.IsEqual {
+		t.Fatalf("comparer for the extension should have decided the equality")
+	}
+
+	result = compareStreams("bin", []byte("0001data"), []byte("0002data"), s)
+	if result.IsEqual {
+		t.Fatalf("comparer should only be used for the registered extension")
+	}
+}
+
+func TestCompareStreams_UsesGlobalComparer(t *testing.T) {
+	SetDefaultStreamComparer(headerInsensitiveComparer)
+	t.Cleanup(ResetDefaults)
+
+	s := newSettings(t)
+	result := compareStreams("png", []byte("0001data"), []byte("0002data"), s)
+	if !result.IsEqual {
+		t.Fatalf("package-level comparer should have decided the equality")
+	}
+}
+
+func TestCompareStreams_ReportsComparerMessage(t *testing.T) {
+	dir := t.TempDir()
+	file := newFilePair("bin", path.Join(dir, "comparer"))
+	utils.File.WriteStream(file.VerifiedPath, []byte("0001data"))
+
+	s := newSettings(t)
+	UseStreamComparer(headerInsensitiveComparer)(s)
+
+	result := comparer.Streams(file, []byte("0002changed"), s)
+	if result.Equality != FileNotEqual {
+		t.Fatalf("should not be equal")
+	}
+	if result.Message != "payload differs" {
+		t.Fatalf("message of the comparer was not returned: %s", result.Message)
+	}
+	if !utils.File.Exists(file.ReceivedPath) {
+		t.Fatalf("received file should have been written")
+	}
+
+	result = comparer.Streams(file, []byte("0003data"), s)
+	if result.Equality != FileEqual {
+		t.Fatalf("should be equal using the comparer")
+	}
+}
@@ -0,0 +1,233 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/diff"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// configFileNames the names of the configuration files, in the order of precedence
+var configFileNames = []string{".verify.yaml", ".verify.yml", ".verify.json"}
+
+var configLocker = &sync.Mutex{}
+
+// configCache the loaded configuration files by path, and the configuration files of the directories
+var configCache = make(map[string]*loadedConfig)
+var configDirectories = make(map[string]*loadedConfig)
+
+// configDiffPath the configuration file whose diff settings were applied
+var configDiffPath string
+
+type loadedConfig struct {
+	config *configFile
+	err    error
+}
+
+// configFile the settings of a `.verify.yaml` or `.verify.json` file, found in the test directory or its parents
+type configFile struct {
+	Directory                string     `yaml:"directory" json:"directory"`
+	ReceivedDirectory        string     `yaml:"receivedDirectory" json:"receivedDirectory"`
+	UniqueForArchitecture    *bool      `yaml:"uniqueForArchitecture" json:"uniqueForArchitecture"`
+	UniqueForOperatingSystem *bool      `yaml:"uniqueForOperatingSystem" json:"uniqueForOperatingSystem"`
+	UniqueForRuntime         *bool      `yaml:"uniqueForRuntime" json:"uniqueForRuntime"`
+	ScrubGuids               *bool      `yaml:"scrubGuids" json:"scrubGuids"`
+	ScrubTimes               *bool      `yaml:"scrubTimes" json:"scrubTimes"`
+	ScrubStackTraces         *bool      `yaml:"scrubStackTraces" json:"scrubStackTraces"`
+	ScrubMachineName         *bool      `yaml:"scrubMachineName" json:"scrubMachineName"`
+	StrictJSON               *bool      `yaml:"strictJson" json:"strictJson"`
+	YAML                     *bool      `yaml:"yaml" json:"yaml"`
+	Diff                     diffConfig `yaml:"diff" json:"diff"`
+	path                     string
+}
+
+type diffConfig struct {
+	Disabled     bool               `yaml:"disabled" json:"disabled"`
+	ToolOrder    []string           `yaml:"toolOrder" json:"toolOrder"`
+	MaxInstances int                `yaml:"maxInstances" json:"maxInstances"`
+	TargetOnLeft *bool              `yaml:"targetOnLeft" json:"targetOnLeft"`
+	CustomTools  []customToolConfig `yaml:"customTools" json:"customTools"`
+}
+
+type customToolConfig struct {
+	Name             string   `yaml:"name" json:"name"`
+	ExePath          string   `yaml:"exePath" json:"exePath"`
+	Arguments        []string `yaml:"arguments" json:"arguments"`
+	AutoRefresh      bool     `yaml:"autoRefresh" json:"autoRefresh"`
+	IsMdi            bool     `yaml:"isMdi" json:"isMdi"`
+	SupportsText     bool     `yaml:"supportsText" json:"supportsText"`
+	RequiresTarget   bool     `yaml:"requiresTarget" json:"requiresTarget"`
+	BinaryExtensions []string `yaml:"binaryExtensions" json:"binaryExtensions"`
+}
+
+// applyConfigFile applies the configuration file of the running test, if there is one.
+// An invalid configuration file fails the test.
+func applyConfigFile(settings *verifySettings) {
+	_, _, sourceFile, _ := testCallerInfo()
+	if len(sourceFile) == 0 {
+		return
+	}
+
+	config, err := getConfigFile(filepath.Dir(sourceFile))
+	if err != nil {
+		settings.t.Fatalf("%s", err)
+		return
+	}
+	if config == nil {
+		return
+	}
+
+	config.apply(settings)
+}
+
+// getConfigFile finds and loads the configuration file for the directory. The files are searched for and loaded
+// once per directory. The diff settings are process-wide, so only the diff settings of the first configuration
+// file loaded by the test binary are applied.
+func getConfigFile(directory string) (*configFile, error) {
+	configLocker.Lock()
+	defer configLocker.Unlock()
+
+	loaded, found := configDirectories[directory]
+	if !found {
+		loaded = loadConfigFile(directory)
+		configDirectories[directory] = loaded
+	}
+	return loaded.config, loaded.err
+}
+
+func loadConfigFile(directory string) *loadedConfig {
+	configPath, found := utils.File.FindInParents(directory, configFileNames...)
+	if !found {
+		return &loadedConfig{}
+	}
+
+	if loaded, found := configCache[configPath]; found {
+		return loaded
+	}
+
+	config, err := readConfigFile(configPath)
+	if err == nil {
+		err = diff.ValidateSettings(config.diffSettings())
+	}
+	if err == nil && len(configDiffPath) == 0 {
+		configDiffPath = configPath
+		err = diff.UseSettings(config.diffSettings())
+	}
+
+	loaded := &loadedConfig{config: config}
+	if err != nil {
+		loaded = &loadedConfig{err: fmt.Errorf("Invalid configuration file %s: %s", configPath, err)}
+	}
+	configCache[configPath] = loaded
+	return loaded
+}
+
+func readConfigFile(configPath string) (*configFile, error) {
+	config := &configFile{path: configPath}
+	content := utils.File.ReadFile(configPath)
+
+	if strings.HasSuffix(configPath, ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, err
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && len(bytes.TrimSpace(content)) > 0 {
+			return nil, err
+		}
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *configFile) validate() error {
+	if c.Diff.MaxInstances < 0 {
+		return fmt.Errorf("diff.maxInstances can't be negative: %d", c.Diff.MaxInstances)
+	}
+
+	for i, tool := range c.Diff.CustomTools {
+		if len(tool.Name) == 0 {
+			return fmt.Errorf("diff.customTools[%d] requires a name", i)
+		}
+	}
+
+	return nil
+}
+
+func (c *configFile) diffSettings() diff.Settings {
+	settings := diff.Settings{
+		Disabled:     c.Diff.Disabled,
+		MaxInstances: c.Diff.MaxInstances,
+		TargetOnLeft: c.Diff.TargetOnLeft,
+	}
+
+	for _, kind := range c.Diff.ToolOrder {
+		settings.ToolOrder = append(settings.ToolOrder, diff.ToolKind(kind))
+	}
+
+	for _, tool := range c.Diff.CustomTools {
+		settings.CustomTools = append(settings.CustomTools, diff.CustomTool{
+			Name:             tool.Name,
+			ExePath:          tool.ExePath,
+			Arguments:        tool.Arguments,
+			AutoRefresh:      tool.AutoRefresh,
+			IsMdi:            tool.IsMdi,
+			SupportsText:     tool.SupportsText,
+			RequiresTarget:   tool.RequiresTarget,
+			BinaryExtensions: tool.BinaryExtensions,
+		})
+	}
+
+	return settings
+}
+
+// apply applies the configuration to the settings. Settings from Defaults and the verifier override them.
+func (c *configFile) apply(settings *verifySettings) {
+	if len(c.Directory) > 0 {
+		directory := c.Directory
+		if !filepath.IsAbs(directory) {
+			directory = filepath.Join(filepath.Dir(c.path), directory)
+		}
+		UseDirectory(directory)(settings)
+	}
+
+	if len(c.ReceivedDirectory) > 0 {
+		directory := c.ReceivedDirectory
+		if !filepath.IsAbs(directory) {
+			directory = filepath.Join(filepath.Dir(c.path), directory)
+		}
+		UseReceivedDirectory(directory)(settings)
+	}
+
+	applyFlag(c.UniqueForArchitecture, &settings.uniqueForArchitecture)
+	applyFlag(c.UniqueForOperatingSystem, &settings.uniqueForOperatingSystem)
+	applyFlag(c.UniqueForRuntime, &settings.uniqueForRuntime)
+	applyFlag(c.ScrubGuids, &settings.scrubGuids)
+	applyFlag(c.ScrubTimes, &settings.scrubTimes)
+	applyFlag(c.ScrubStackTraces, &settings.scrubStackTraces)
+	applyFlag(c.StrictJSON, &settings.strictJSON)
+	applyFlag(c.YAML, &settings.yaml)
+
+	if c.ScrubMachineName != nil && *c.ScrubMachineName {
+		ScrubMachineName()(settings)
+	}
+
+	settings.diffDisabled = diff.CheckDisabled()
+}
+
+func applyFlag(value *bool, target *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
@@ -0,0 +1,93 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, name)
+	utils.File.WriteText(configPath, content)
+	return configPath
+}
+
+func TestConfigFile_Yaml(t *testing.T) {
+	configPath := writeConfigFile(t, ".verify.yaml", `
+directory: snapshots
+uniqueForOperatingSystem: true
+scrubGuids: false
+diff:
+  toolOrder: [Meld, VisualStudioCode]
+  maxInstances: 2
+`)
+
+	config, err := readConfigFile(configPath)
+	if err != nil {
+		t.Fatalf("Should read the config file: %s", err)
+	}
+
+	settings := newSettings(t)
+	config.apply(settings)
+
+	if settings.directory != filepath.Join(filepath.Dir(configPath), "snapshots") {
+		t.Fatalf("Should resolve the directory relative to the config file, got %s", settings.directory)
+	}
+	if !settings.uniqueForOperatingSystem || settings.scrubGuids {
+		t.Fatalf("Should apply the flags")
+	}
+	if !settings.scrubTimes {
+		t.Fatalf("Should keep the defaults that are not configured")
+	}
+
+	diffSettings := config.diffSettings()
+	if len(diffSettings.ToolOrder) != 2 || diffSettings.ToolOrder[0] != "Meld" || diffSettings.MaxInstances != 2 {
+		t.Fatalf("Should read the diff settings: %v", diffSettings)
+	}
+}
+
+func TestConfigFile_Json(t *testing.T) {
+	configPath := writeConfigFile(t, ".verify.json", `{
+  "uniqueForRuntime": true,
+  "diff": {
+    "customTools": [{"name": "mytool", "exePath": "/usr/bin/mytool", "arguments": ["{tempFile}", "{targetFile}"]}]
+  }
+}`)
+
+	config, err := readConfigFile(configPath)
+	if err != nil {
+		t.Fatalf("Should read the config file: %s", err)
+	}
+	if config.UniqueForRuntime == nil || !*config.UniqueForRuntime {
+		t.Fatalf("Should read the flags")
+	}
+	if tools := config.diffSettings().CustomTools; len(tools) != 1 || tools[0].ExePath != "/usr/bin/mytool" {
+		t.Fatalf("Should read the custom tools: %v", tools)
+	}
+}
+
+func TestConfigFile_UnknownField(t *testing.T) {
+	configPath := writeConfigFile(t, ".verify.yaml", "directry: snapshots")
+
+	if _, err := readConfigFile(configPath); err == nil || !strings.Contains(err.Error(), "directry") {
+		t.Fatalf("Should report the unknown field, got %v", err)
+	}
+}
+
+func TestConfigFile_InvalidDiffSettings(t *testing.T) {
+	configPath := writeConfigFile(t, ".verify.yaml", `
+diff:
+  toolOrder: [NotATool]
+`)
+
+	_, err := getConfigFile(filepath.Dir(configPath))
+	if err == nil || !strings.Contains(err.Error(), "Invalid configuration file") || !strings.Contains(err.Error(), "NotATool") {
+		t.Fatalf("Should report the invalid tool, got %v", err)
+	}
+
+	if _, cached := getConfigFile(filepath.Dir(configPath)); cached != err {
+		t.Fatalf("Should load the configuration file of the directory once")
+	}
+}
@@ -0,0 +1,79 @@
+package verifier
+
+import (
+	"reflect"
+	"sync"
+)
+
+// defaults the settings and the registrations shared by all the verifiers
+type defaults struct {
+	configure             []VerifyConfigure
+	stringComparers       map[string]StringComparerFunc
+	defaultStringComparer StringComparerFunc
+	streamComparers       map[string]StreamComparerFunc
+	defaultStreamComparer StreamComparerFunc
+	fileConvention        FileConventionFunc
+	converters            map[reflect.Type]TypeConverterFunc
+	fileConverters        map[string]FileConverterFunc
+}
+
+var defaultsLocker = &sync.RWMutex{}
+var globalDefaults = newDefaults()
+
+func newDefaults() *defaults {
+	return &defaults{
+		configure:       make([]VerifyConfigure, 0),
+		stringComparers: make(map[string]StringComparerFunc),
+		streamComparers: make(map[string]StreamComparerFunc),
+		converters:      make(map[reflect.Type]TypeConverterFunc),
+		fileConverters:  make(map[string]FileConverterFunc),
+	}
+}
+
+// Defaults registers settings that are applied to every new verifier, before its own settings.
+// It is usually called once from `TestMain`.
+func Defaults(configure ...VerifyConfigure) {
+	defaultsLocker.Lock()
+	defer defaultsLocker.Unlock()
+
+	globalDefaults.configure = append(globalDefaults.configure, configure...)
+}
+
+// ResetDefaults removes all the settings registered via Defaults, and the comparers, converters and file convention
+// registered for all the verifications. Tests that change the defaults can restore them with `t.Cleanup(ResetDefaults)`.
+func ResetDefaults() {
+	defaultsLocker.Lock()
+	defer defaultsLocker.Unlock()
+
+	globalDefaults = newDefaults()
+}
+
+// updateDefaults changes the defaults while holding the lock
+func updateDefaults(update func(d *defaults)) {
+	defaultsLocker.Lock()
+	defer defaultsLocker.Unlock()
+
+	update(globalDefaults)
+}
+
+// readDefaults reads the defaults while holding the lock
+func readDefaults(read func(d *defaults)) {
+	defaultsLocker.RLock()
+	defer defaultsLocker.RUnlock()
+
+	read(globalDefaults)
+}
+
+func applyDefaults(settings *verifySettings) {
+	readDefaults(func(d *defaults) {
+		applyConfigure(settings, d.configure)
+	})
+}
+
+func applyConfigure(settings *verifySettings, configure []VerifyConfigure) {
+	for _, cfg := range configure {
+		if cfg != nil {
+			cfg(settings)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package verifier
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaults_AppliedBeforeVerifierSettings(t *testing.T) {
+	Defaults(UseDirectory("defaults"), TestCase("defaults"), DisableDiff())
+	defer ResetDefaults()
+
+	v := NewVerifier(t, UseDirectory("local")).(*verifier)
+
+	if v.settings.directory != "local" {
+		t.Fatalf("Should override the defaults, got %s", v.settings.directory)
+	}
+	if v.settings.testCase != "defaults" || !v.settings.diffDisabled {
+		t.Fatalf("Should apply the defaults")
+	}
+}
+
+func TestResetDefaults(t *testing.T) {
+	Defaults(TestCase("defaults"))
+	ResetDefaults()
+
+	v := NewVerifier(t).(*verifier)
+	if len(v.settings.testCase) > 0 {
+		t.Fatalf("Should not apply the removed defaults")
+	}
+}
+
+func TestResetDefaults_Registrations(t *testing.T) {
+	RegisterStringComparer("sql", func(received, verified string) CompareResult { return CompareResult{IsEqual: true} })
+	SetDefaultStreamComparer(func(received, verified []byte) CompareResult { return CompareResult{IsEqual: true} })
+	SetDefaultFileConvention(defaultFileConvention)
+	RegisterConverter(Address{}, func(value interface{}, writer *Writer) {})
+	RegisterFileConverter("lines", splitLines)
+	ResetDefaults()
+
+	s := newSettings(t)
+	if _, found := tryGetGlobalStringComparer("sql"); found {
+		t.Fatalf("Should remove the string comparers")
+	}
+	if _, found := tryGetGlobalStreamComparer("bin"); found {
+		t.Fatalf("Should remove the default stream comparer")
+	}
+	if getGlobalFileConvention() != nil {
+		t.Fatalf("Should remove the file convention")
+	}
+	if s.hasConverter(reflect.TypeOf(Address{})) {
+		t.Fatalf("Should remove the converters")
+	}
+	if _, found := s.tryGetFileConverter("lines"); found {
+		t.Fatalf("Should remove the file converters")
+	}
+}
@@ -0,0 +1,116 @@
+package verifier
+
+import (
+	"fmt"
+	"github.com/heskandari/jsoner"
+	"github.com/modern-go/reflect2"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// emptyValueExtension omits the struct members with zero values, nil pointers, and nil slices and maps.
+// Empty slices and maps that are not nil are kept, and written as `[]` and `{}`.
+type emptyValueExtension struct {
+	jsoner.DummyExtension
+}
+
+// omitEmptyField adds `omitempty` to the json tag of the field, so jsoner checks the encoder before writing it
+type omitEmptyField struct {
+	reflect2.StructField
+}
+
+type emptyValueEncoder struct {
+	encoder   jsoner.ValEncoder
+	fieldType reflect2.Type
+	omitEmpty bool
+}
+
+// UpdateStructDescriptor replaces the fields and the encoders of all the members, to omit the empty values
+func (e *emptyValueExtension) UpdateStructDescriptor(structDescriptor *jsoner.StructDescriptor) {
+	for _, binding := range structDescriptor.Fields {
+		binding.Encoder = &emptyValueEncoder{
+			encoder:   binding.Encoder,
+			fieldType: binding.Field.Type(),
+			omitEmpty: hasOmitEmpty(binding.Field.Tag()),
+		}
+		binding.Field = &omitEmptyField{StructField: binding.Field}
+	}
+}
+
+func hasOmitEmpty(tag reflect.StructTag) bool {
+	options := strings.Split(tag.Get("json"), ",")
+	for _, option := range options[1:] {
+		if option == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
+
+// Tag returns the tag of the field with `omitempty` added to the json tag
+func (f *omitEmptyField) Tag() reflect.StructTag {
+	tag := f.StructField.Tag()
+	value, found := tag.Lookup("json")
+	if !found {
+		return reflect.StructTag(strings.TrimSpace(fmt.Sprintf(`json:",omitempty" %s`, tag)))
+	}
+	if hasOmitEmpty(tag) {
+		return tag
+	}
+	return reflect.StructTag(strings.Replace(string(tag),
+		fmt.Sprintf(`json:"%s"`, value),
+		fmt.Sprintf(`json:"%s,omitempty"`, value), 1))
+}
+
+// IsEmpty checks for zero values and nil collections. Explicit `omitempty` tags keep their meaning.
+func (e *emptyValueEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	if e.omitEmpty && e.encoder.IsEmpty(ptr) {
+		return true
+	}
+
+	value := reflect.ValueOf(e.fieldType.UnsafeIndirect(ptr))
+	if !value.IsValid() {
+		return true
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.IsNil()
+	}
+	return value.IsZero()
+}
+
+func (e *emptyValueEncoder) Encode(ptr unsafe.Pointer, stream *jsoner.Stream) {
+	e.encoder.Encode(ptr, stream)
+}
+
+// IgnoreEmptyValues omits the struct members with zero values, nil pointers, and nil slices and maps from the
+// serialized targets. Empty slices and maps that are not nil are written as `[]` and `{}`.
+// This is the default for the .txt files.
+func IgnoreEmptyValues() VerifyConfigure {
+	return func(s *verifySettings) {
+		s.ignoreEmptyValues = true
+		s.includeDefaults = false
+	}
+}
+
+// IncludeDefaults writes all the struct members, including the zero values, to the serialized targets.
+// Members tagged with `json:",omitempty"` are still omitted when empty.
+func IncludeDefaults() VerifyConfigure {
+	return func(s *verifySettings) {
+		s.includeDefaults = true
+		s.ignoreEmptyValues = false
+	}
+}
+
+// shouldIgnoreEmptyValues empty values are ignored in the relaxed format of the .txt files, unless configured otherwise
+func (s *verifySettings) shouldIgnoreEmptyValues() bool {
+	if s.includeDefaults {
+		return false
+	}
+	if s.ignoreEmptyValues {
+		return true
+	}
+	return !s.strictJSON && !s.yaml
+}
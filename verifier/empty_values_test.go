@@ -0,0 +1,75 @@
+package verifier
+
+import (
+	"github.com/modern-go/reflect2"
+	"reflect"
+	"testing"
+)
+
+type taggedFields struct {
+	Plain   string
+	Named   string `json:"named"`
+	Omitted string `json:"omitted,omitempty"`
+	Other   string `yaml:"other" json:"other,string"`
+}
+
+func TestOmitEmptyField_Tag(t *testing.T) {
+	structType := reflect2.TypeOf(taggedFields{}).(reflect2.StructType)
+
+	expected := map[string]reflect.StructTag{
+		"Plain":   `json:",omitempty"`,
+		"Named":   `json:"named,omitempty"`,
+		"Omitted": `json:"omitted,omitempty"`,
+		"Other":   `yaml:"other" json:"other,string,omitempty"`,
+	}
+	for name, tag := range expected {
+		field := &omitEmptyField{StructField: structType.FieldByName(name)}
+		if field.Tag() != tag {
+			t.Fatalf("Should add omitempty to the json tag of %s, got %s", name, field.Tag())
+		}
+	}
+}
+
+func TestShouldIgnoreEmptyValues(t *testing.T) {
+	s := newSettings(t)
+	if !s.shouldIgnoreEmptyValues() {
+		t.Fatalf("Should ignore empty values in the txt files")
+	}
+
+	UseStrictJSON()(s)
+	if s.shouldIgnoreEmptyValues() {
+		t.Fatalf("Should include the empty values in the json files")
+	}
+
+	IgnoreEmptyValues()(s)
+	if !s.shouldIgnoreEmptyValues() {
+		t.Fatalf("Should ignore empty values when configured")
+	}
+
+	IncludeDefaults()(s)
+	if s.shouldIgnoreEmptyValues() {
+		t.Fatalf("Should include the empty values when configured")
+	}
+}
+
+type ignoredMembersOwner struct {
+	Name  string
+	Inner ignoredMembers
+	Empty ignoredMembers
+}
+
+type ignoredMembers struct {
+	Note string
+}
+
+// The members whose values are not empty are kept, even when all their own members are ignored,
+// so the serialized target still shows that the member exists.
+func TestIgnoreEmptyValues_AllMembersIgnored(t *testing.T) {
+	s := newSettings(t)
+	IgnoreMember(ignoredMembers{}, "Note")(s)
+
+	result := asJSON(ignoredMembersOwner{Name: "owner", Inner: ignoredMembers{Note: "note"}}, nil, s).String()
+	if result != "{\n  Name: owner,\n  Inner: {}\n}" {
+		t.Fatalf("Should write the struct with ignored members as {}, and omit the zero struct:\n%s", result)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 type engine struct {
 	directory           string
+	receivedDirectory   string
 	deletedFiles        []string
 	testing             testingT
 	settings            *verifySettings
@@ -24,6 +25,7 @@ type engine struct {
 func newEngine(
 	testing testingT,
 	directory string,
+	receivedDirectory string,
 	settings *verifySettings,
 	verifiedFiles []string,
 	getFileNames getFileNamesFunc,
@@ -32,6 +34,7 @@ func newEngine(
 	return &engine{
 		testing:             testing,
 		directory:           directory,
+		receivedDirectory:   receivedDirectory,
 		settings:            settings,
 		getFileNames:        getFileNames,
 		getIndexedFileNames: getIndexedFileNames,
@@ -67,7 +70,8 @@ func (e *engine) handleResults(targetList []Target) {
 	}
 }
 
-func (e *engine) throwIfRequired() {
+// getVerificationError processes the results, and returns the error when the verification failed
+func (e *engine) getVerificationError() *VerificationError {
 	e.processEquals()
 
 	noChanges := len(e.newFiles) == 0 &&
@@ -75,7 +79,7 @@ func (e *engine) throwIfRequired() {
 		len(e.deletedFiles) == 0
 
 	if noChanges {
-		return
+		return nil
 	}
 
 	e.processDeletes()
@@ -84,10 +88,23 @@ func (e *engine) throwIfRequired() {
 
 	e.processNotEquals()
 
-	if !e.settings.autoVerify {
+	if !e.settings.acceptsChanges() {
 		errorBuilder := e.newErrorBuilder()
-		e.testing.Errorf(errorBuilder.build())
+		return &VerificationError{
+			TestName:          errorBuilder.testName,
+			Directory:         e.directory,
+			ReceivedDirectory: e.receivedDirectory,
+			NewFiles:          e.newFiles,
+			NotEqualFiles:     e.notEqualFiles,
+			EqualFiles:        e.equalFiles,
+			DeletedFiles:      e.deletedFiles,
+			message:           errorBuilder.build(),
+		}
 	}
+
+	e.testing.Logf("AutoVerify accepted %d new, %d changed, and deleted %d verified file(s)",
+		len(e.newFiles), len(e.notEqualFiles), len(e.deletedFiles))
+	return nil
 }
 
 func (e *engine) newErrorBuilder() failingMessageBuilder {
@@ -96,6 +113,7 @@ func (e *engine) newErrorBuilder() failingMessageBuilder {
 		testCase:      e.settings.testCase,
 		testName:      normalizeTestName(e.testing.Name()),
 		directory:     e.directory,
+		received:      e.receivedDirectory,
 		notEqualFiles: e.notEqualFiles,
 		equalFiles:    e.equalFiles,
 		newFiles:      e.newFiles,
@@ -151,8 +169,10 @@ func (e *engine) processDelete(deletedFile string) {
 
 	e.settings.runOnVerifyDelete(deletedFile)
 
-	if e.settings.autoVerify {
+	// AutoVerify is ignored on CI, unless the update mode was explicitly allowed on CI
+	if e.settings.acceptsChanges() {
 		utils.File.Delete(deletedFile)
+		e.testing.Logf("Deleted: %s", deletedFile)
 		return
 	}
 
@@ -221,6 +241,10 @@ func (e *engine) getResult(filePair FilePair, target Target, previousTextHasFail
 
 func (e *engine) runDiffAutoCheck(item FilePair) {
 	if e.settings.ciDetected == true {
+		// AutoVerify is ignored on CI, unless the update mode was explicitly allowed on CI
+		if e.settings.acceptsChanges() {
+			e.acceptChanges(item)
+		}
 		return
 	}
 
@@ -237,4 +261,5 @@ func (e *engine) runDiffAutoCheck(item FilePair) {
 func (e *engine) acceptChanges(item FilePair) {
 	utils.File.Delete(item.VerifiedPath)
 	utils.File.Move(item.ReceivedPath, item.VerifiedPath)
+	e.testing.Logf("Accepted: %s", item.VerifiedPath)
 }
@@ -10,6 +10,7 @@ import (
 type failingMessageBuilder struct {
 	settings      *verifySettings
 	directory     string
+	received      string
 	notEqualFiles NotEqualFiles
 	equalFiles    EqualFiles
 	newFiles      NewFiles
@@ -33,6 +34,10 @@ func (b *failingMessageBuilder) build() string {
 
 	builder.WriteString(fmt.Sprintf("Directory: %s\n", b.directory))
 
+	if len(b.received) > 0 && b.received != b.directory {
+		builder.WriteString(fmt.Sprintf("Received Directory: %s\n", b.received))
+	}
+
 	if len(b.newFiles) > 0 {
 		builder.WriteString("New:\n")
 		for _, f := range b.newFiles {
@@ -109,16 +114,13 @@ func (b *failingMessageBuilder) appendContent(builder *strings.Builder) {
 	if len(notEqualContentFiles) > 0 {
 		builder.WriteString("  NotEqual:\n")
 		for _, item := range notEqualContentFiles {
+			builder.WriteString(fmt.Sprintf("  - Received: %s\n", item.File.ReceivedName))
+			builder.WriteString(fmt.Sprintf("    Verified: %s\n", item.File.VerifiedName))
 			if len(item.Message) == 0 {
-				builder.WriteString(fmt.Sprintf("  - Received: %s\n", item.File.ReceivedName))
-				builder.Write(utils.File.ReadFile(item.File.ReceivedPath))
-				builder.WriteRune('\n')
-				builder.WriteString(fmt.Sprintf("    Verified: %s\n", item.File.ReceivedName))
-				builder.Write(utils.File.ReadFile(item.File.VerifiedPath))
-				builder.WriteRune('\n')
+				received := string(utils.File.ReadFile(item.File.ReceivedPath))
+				verified := string(utils.File.ReadFile(item.File.VerifiedPath))
+				builder.WriteString(unifiedDiff(b.settings.textDiff, item.File.VerifiedName, item.File.ReceivedName, verified, received))
 			} else {
-				builder.WriteString(fmt.Sprintf("  - Received: %s\n", item.File.ReceivedName))
-				builder.WriteString(fmt.Sprintf("    Verified: %s\n", item.File.VerifiedName))
 				builder.WriteString(fmt.Sprintf("    Compare Result: %s\n", item.Message))
 			}
 			builder.WriteString("\n")
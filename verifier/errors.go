@@ -0,0 +1,153 @@
+package verifier
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// maxErrorDepth limits the depth of the error chain, to protect against errors that wrap themselves
+const maxErrorDepth = 32
+
+// errorTarget the serializable form of an error and the errors it wraps
+type errorTarget struct {
+	Type    string                 `json:"type"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Wrapped []errorTarget          `json:"wrapped,omitempty"`
+}
+
+// panicTarget the serializable form of a recovered panic
+type panicTarget struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+func newErrorTarget(err error) errorTarget {
+	return newErrorTargetWithDepth(err, 0)
+}
+
+func newErrorTargetWithDepth(err error, depth int) errorTarget {
+	wrapped := unwrapErrors(err)
+
+	target := errorTarget{
+		Type:    fmt.Sprintf("%T", err),
+		Message: err.Error(),
+		Fields:  getErrorFields(err, wrapped),
+	}
+
+	if depth >= maxErrorDepth {
+		return target
+	}
+
+	for _, inner := range wrapped {
+		target.Wrapped = append(target.Wrapped, newErrorTargetWithDepth(inner, depth+1))
+	}
+	return target
+}
+
+// unwrapErrors returns the errors wrapped with `Unwrap() error`, or joined with `Unwrap() []error`
+func unwrapErrors(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		result := make([]error, 0)
+		for _, inner := range e.Unwrap() {
+			if inner != nil {
+				result = append(result, inner)
+			}
+		}
+		return result
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return []error{inner}
+		}
+	}
+	return nil
+}
+
+// getErrorFields returns the exported fields of the error struct. The wrapped errors are part of the chain,
+// so they are skipped, and other errors are replaced by their messages.
+func getErrorFields(err error, wrapped []error) map[string]interface{} {
+	value := reflect.ValueOf(err)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make(map[string]interface{})
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+
+		fieldValue := value.Field(i).Interface()
+		if fieldError, ok := fieldValue.(error); ok {
+			if isWrappedError(fieldError, wrapped) {
+				continue
+			}
+			if isNilValue(fieldError) {
+				fieldValue = nil
+			} else {
+				fieldValue = fieldError.Error()
+			}
+		}
+		fields[field.Name] = fieldValue
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// isNilValue checks if the error is a typed nil, e.g. a nil *MyError stored in an error field
+func isNilValue(err error) bool {
+	value := reflect.ValueOf(err)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+func isWrappedError(err error, wrapped []error) bool {
+	for _, inner := range wrapped {
+		if reflect.TypeOf(inner).Comparable() && reflect.TypeOf(err).Comparable() && inner == err {
+			return true
+		}
+	}
+	return false
+}
+
+func newPanicTarget(recovered interface{}) panicTarget {
+	if err, ok := recovered.(error); ok {
+		return panicTarget{
+			Type:  fmt.Sprintf("%T", recovered),
+			Value: newErrorTarget(err),
+		}
+	}
+
+	return panicTarget{
+		Type:  fmt.Sprintf("%T", recovered),
+		Value: recovered,
+	}
+}
+
+// capturePanic runs the function and returns the recovered value
+func capturePanic(fun func()) (recovered interface{}, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			recovered = r
+			panicked = true
+		}
+	}()
+
+	fun()
+	return nil, false
+}
@@ -0,0 +1,97 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type selfWrappingError struct{}
+
+func (e *selfWrappingError) Error() string { return "self" }
+func (e *selfWrappingError) Unwrap() error { return e }
+
+type queryError struct {
+	Query string
+	Err   error
+	Cause error
+}
+
+func (e *queryError) Error() string { return "query failed" }
+func (e *queryError) Unwrap() error { return e.Err }
+
+type codeError struct {
+	Code int
+}
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.Code) }
+
+type requestError struct {
+	Cause error
+}
+
+func (e *requestError) Error() string { return "request failed" }
+
+func TestNewErrorTarget_Chain(t *testing.T) {
+	inner := errors.New("inner")
+	target := newErrorTarget(fmt.Errorf("outer: %w", inner))
+
+	if target.Type != "*fmt.wrapError" || target.Message != "outer: inner" {
+		t.Fatalf("Should describe the outer error: %+v", target)
+	}
+	if len(target.Wrapped) != 1 || target.Wrapped[0].Message != "inner" {
+		t.Fatalf("Should include the wrapped error: %+v", target.Wrapped)
+	}
+}
+
+func TestNewErrorTarget_Fields(t *testing.T) {
+	target := newErrorTarget(&queryError{Query: "select 1", Err: errors.New("wrapped"), Cause: errors.New("cause")})
+
+	if target.Fields["Query"] != "select 1" {
+		t.Fatalf("Should include the exported fields: %+v", target.Fields)
+	}
+	if _, found := target.Fields["Err"]; found {
+		t.Fatalf("Should not repeat the wrapped error as a field: %+v", target.Fields)
+	}
+	if target.Fields["Cause"] != "cause" {
+		t.Fatalf("Should write other errors as their message: %+v", target.Fields)
+	}
+}
+
+func TestNewErrorTarget_TypedNilField(t *testing.T) {
+	var cause *codeError
+	target := newErrorTarget(&requestError{Cause: cause})
+
+	if value, found := target.Fields["Cause"]; !found || value != nil {
+		t.Fatalf("Should write the typed nil error as null: %+v", target.Fields)
+	}
+}
+
+func TestNewErrorTarget_MaxDepth(t *testing.T) {
+	target := newErrorTarget(&selfWrappingError{})
+
+	depth := 0
+	for len(target.Wrapped) > 0 {
+		target = target.Wrapped[0]
+		depth++
+	}
+	if depth > maxErrorDepth {
+		t.Fatalf("Should stop unwrapping at the maximum depth, got %d", depth)
+	}
+}
+
+func TestCapturePanic(t *testing.T) {
+	recovered, panicked := capturePanic(func() { panic("boom") })
+	if !panicked || recovered != "boom" {
+		t.Fatalf("Should recover the panic value: %v", recovered)
+	}
+
+	if _, panicked = capturePanic(func() {}); panicked {
+		t.Fatalf("Should not report a panic for a function that returns normally")
+	}
+
+	target := newPanicTarget(errors.New("failed"))
+	if _, ok := target.Value.(errorTarget); !ok {
+		t.Fatalf("Should convert panicking errors to error targets: %+v", target)
+	}
+}
@@ -0,0 +1,77 @@
+package verifier
+
+import (
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"strings"
+)
+
+// FileConverterFunc converts the content of a file to the targets that are verified instead of it, e.g. the entries
+// of an archive. The info is serialized and verified with the targets, and can be nil.
+type FileConverterFunc func(data []byte) (targets []Target, info interface{})
+
+// RegisterFileConverter registers the converter of the files with the extension, e.g. `zip`, for all the verifications.
+// The converters added with AddFileConverter take precedence.
+func RegisterFileConverter(extension string, fun FileConverterFunc) {
+	utils.Guard.AgainstBadExtension(extension)
+	updateDefaults(func(d *defaults) {
+		d.fileConverters[extension] = fun
+	})
+}
+
+// AddFileConverter uses the converter to verify the streams with the extension, e.g. `zip`
+func AddFileConverter(extension string, fun FileConverterFunc) VerifyConfigure {
+	utils.Guard.AgainstBadExtension(extension)
+	return func(s *verifySettings) {
+		s.fileConverters[extension] = fun
+	}
+}
+
+// NewStringTarget creates a text target with the extension, e.g. `txt` or `csv`
+func NewStringTarget(extension string, text string) Target {
+	return *newStringTarget(extension, fixNewlines(text))
+}
+
+// NewStreamTarget creates a binary target with the extension, e.g. `png`
+func NewStreamTarget(extension string, data []byte) Target {
+	return *newStreamTarget(extension, data)
+}
+
+func (s *verifySettings) tryGetFileConverter(extension string) (converter FileConverterFunc, found bool) {
+	if converter, found = s.fileConverters[extension]; found {
+		return
+	}
+
+	readDefaults(func(d *defaults) {
+		converter, found = d.fileConverters[extension]
+	})
+	return
+}
+
+// convertStream converts the stream with the converter, and scrubs the converted text targets.
+// A converter that returns neither targets nor info leaves nothing to verify, which is reported as an error.
+func (v *innerVerifier) convertStream(converter FileConverterFunc, data []byte, extension string) ([]Target, interface{}, error) {
+	targets, info := converter(data)
+	if len(targets) == 0 && isNil(info) {
+		return nil, nil, fmt.Errorf("The converter of the %s files returned no targets", extension)
+	}
+
+	scrubbed := make([]Target, 0, len(targets))
+	for _, target := range targets {
+		if target.IsStream() {
+			scrubbed = append(scrubbed, target)
+			continue
+		}
+
+		builder := &strings.Builder{}
+		if target.IsStringBuilder() {
+			builder.WriteString(target.GetStringBuilderData().String())
+		} else {
+			builder.WriteString(target.GetStringData())
+		}
+		v.scrubber.Apply(target.GetExtension(), builder, v.settings)
+		scrubbed = append(scrubbed, *newStringTarget(target.GetExtension(), builder.String()))
+	}
+
+	return scrubbed, info, nil
+}
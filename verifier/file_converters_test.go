@@ -0,0 +1,79 @@
+package verifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func splitLines(data []byte) ([]Target, interface{}) {
+	targets := make([]Target, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		targets = append(targets, NewStringTarget("txt", line))
+	}
+	return targets, len(targets)
+}
+
+func TestAddFileConverter_TakesPrecedence(t *testing.T) {
+	RegisterFileConverter("lines", func(data []byte) ([]Target, interface{}) {
+		return nil, "global"
+	})
+	t.Cleanup(ResetDefaults)
+
+	s := newSettings(t)
+	converter, ok := s.tryGetFileConverter("lines")
+	if _, info := converter(nil); !ok || info != "global" {
+		t.Fatalf("Should find the global converter")
+	}
+
+	AddFileConverter("lines", splitLines)(s)
+	converter, ok = s.tryGetFileConverter("lines")
+	if _, info := converter([]byte("a\nb")); !ok || info != 2 {
+		t.Fatalf("Should use the converter of the verifier")
+	}
+
+	if _, ok := s.tryGetFileConverter("zip"); ok {
+		t.Fatalf("Should not find converters of other extensions")
+	}
+}
+
+func TestConvertStream_ScrubsTextTargets(t *testing.T) {
+	s := newSettings(t)
+	ScrubInlineGuids()(s)
+	inner := &innerVerifier{scrubber: s.scrubber, settings: s}
+
+	targets, info, _ := inner.convertStream(splitLines, []byte("id: 1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed\nname"), "lines")
+
+	if info != 2 || len(targets) != 2 {
+		t.Fatalf("Should return the converted targets and the info")
+	}
+	if text := targets[0].GetStringData(); text != "id: Guid_1" {
+		t.Fatalf("Should scrub the text targets, got %s", text)
+	}
+}
+
+func TestConvertStream_NoTargets(t *testing.T) {
+	s := newSettings(t)
+	inner := &innerVerifier{scrubber: s.scrubber, settings: s}
+
+	_, _, err := inner.convertStream(func(data []byte) ([]Target, interface{}) {
+		return nil, nil
+	}, []byte("data"), "lines")
+
+	if err == nil || !strings.Contains(err.Error(), "lines") {
+		t.Fatalf("Should report the converter that returned nothing, got %v", err)
+	}
+}
+
+func TestVerifyStream_NoTargets(t *testing.T) {
+	verifier := NewExtendedVerifier(t,
+		UseDirectory(t.TempDir()),
+		UseExtension("lines"),
+		AddFileConverter("lines", func(data []byte) ([]Target, interface{}) {
+			return nil, nil
+		}))
+
+	err := verifier.VerifyE(strings.NewReader("data"))
+	if err == nil || !strings.Contains(err.Error(), "returned no targets") {
+		t.Fatalf("Should fail the verification, got %v", err)
+	}
+}
@@ -0,0 +1,365 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultScrubbedHTTPHeaders headers that change between requests and are scrubbed by default
+var defaultScrubbedHTTPHeaders = []string{
+	"Age",
+	"Content-Length",
+	"Date",
+	"Etag",
+	"Expires",
+	"Last-Modified",
+	"Set-Cookie",
+}
+
+// httpBodyExtensions the extensions of the well-known binary content types.
+// Other content types are looked up with mime.ExtensionsByType.
+var httpBodyExtensions = map[string]string{
+	"application/octet-stream": "bin",
+	"application/pdf":          "pdf",
+	"application/zip":          "zip",
+	"image/gif":                "gif",
+	"image/jpeg":               "jpg",
+	"image/png":                "png",
+	"image/webp":               "webp",
+}
+
+var (
+	xmlTextEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	xmlAttributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+func newScrubbedHTTPHeaders() map[string]bool {
+	headers := make(map[string]bool)
+	for _, header := range defaultScrubbedHTTPHeaders {
+		headers[header] = true
+	}
+	return headers
+}
+
+// VerifyHTTPResponse verifies the status line, the headers and the body of the response with the default settings.
+// See Verifier.VerifyHTTPResponse.
+func VerifyHTTPResponse(t testingT, response *http.Response) {
+	newVerifier(t, nil).VerifyHTTPResponse(response)
+}
+
+// VerifyHandler serves the request with the handler and verifies the response with the default settings.
+// See Verifier.VerifyHTTPResponse.
+func VerifyHandler(t testingT, handler http.Handler, request *http.Request) {
+	newVerifier(t, nil).VerifyHandler(handler, request)
+}
+
+// VerifyHTTPResponse verifies the status line, the sorted headers and the body of the response.
+// JSON, XML and HTML bodies are indented, and binary bodies are verified as a separate file with
+// the extension of the content type. The values of volatile headers, such as `Date` and `Set-Cookie`, are scrubbed.
+// The response body is read and closed.
+func (v *verifier) VerifyHTTPResponse(response *http.Response) {
+	if err := v.verifyHTTPResponse(response); err != nil {
+		v.settings.t.Error(err.Error())
+	}
+}
+
+// VerifyHandler serves the request with the handler and verifies the recorded response like VerifyHTTPResponse.
+func (v *verifier) VerifyHandler(handler http.Handler, request *http.Request) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	v.VerifyHTTPResponse(recorder.Result())
+}
+
+func (v *verifier) verifyHTTPResponse(response *http.Response) *VerificationError {
+	if response == nil {
+		return v.verify(nil)
+	}
+
+	v.assertExtensionIsNull()
+	snapshot, targets := newHTTPSnapshot(response, v.settings)
+
+	inner := createInnerVerifier(v.settings.t, v.settings)
+	defer v.settings.runAfterVerify()
+
+	return inner.verifyInner(snapshot, nil, targets)
+}
+
+// newHTTPSnapshot formats the response as text. A binary body is returned as a separate target.
+func newHTTPSnapshot(response *http.Response, settings *verifySettings) (string, []Target) {
+	builder := strings.Builder{}
+	builder.WriteString(getStatusLine(response))
+	builder.WriteString("\n")
+	writeHTTPHeaders(&builder, response.Header, settings.scrubbedHTTPHeaders)
+
+	body := readHTTPBody(response)
+	if len(body) == 0 {
+		return builder.String(), emptyTargets
+	}
+
+	text, extension, isText := formatHTTPBody(response.Header.Get("Content-Type"), body)
+	if !isText {
+		return builder.String(), []Target{*newStreamTarget(extension, body)}
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(text)
+	return builder.String(), emptyTargets
+}
+
+func getStatusLine(response *http.Response) string {
+	proto := response.Proto
+	if len(proto) == 0 {
+		proto = "HTTP/1.1"
+	}
+
+	status := response.Status
+	if len(status) == 0 {
+		status = fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return fmt.Sprintf("%s %s", proto, status)
+}
+
+func writeHTTPHeaders(builder *strings.Builder, header http.Header, scrubbed map[string]bool) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			builder.WriteString(fmt.Sprintf("%s: %s\n", name, scrubHTTPHeader(name, value, scrubbed)))
+		}
+	}
+}
+
+func scrubHTTPHeader(name, value string, scrubbed map[string]bool) string {
+	canonicalName := http.CanonicalHeaderKey(name)
+	if !scrubbed[canonicalName] {
+		return value
+	}
+
+	if canonicalName == "Set-Cookie" {
+		return scrubCookie(value)
+	}
+	return scrubbedValue
+}
+
+// scrubCookie scrubs the value and the expiry date of the cookie, and keeps its name and the other attributes
+func scrubCookie(cookie string) string {
+	parts := strings.Split(cookie, ";")
+	for i, part := range parts {
+		index := strings.Index(part, "=")
+		if index == -1 {
+			continue
+		}
+		name := part[:index]
+		if i == 0 || strings.EqualFold(strings.TrimSpace(name), "Expires") {
+			parts[i] = name + "=" + scrubbedValue
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+func readHTTPBody(response *http.Response) []byte {
+	if response.Body == nil {
+		return nil
+	}
+
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read the response body: %s", err))
+	}
+	return body
+}
+
+// formatHTTPBody pretty-prints text bodies based on the content type, or returns the extension for binary bodies
+func formatHTTPBody(contentType string, body []byte) (text string, extension string, isText bool) {
+	if len(contentType) == 0 {
+		contentType = http.DetectContentType(body)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	extension = getHTTPBodyExtension(mediaType)
+	if !isTextMediaType(mediaType, extension) || !utf8.Valid(body) {
+		if utils.File.IsText(extension) {
+			extension = "bin"
+		}
+		return "", extension, false
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return indentJSON(body), extension, true
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return indentMarkup(body, false), extension, true
+	case mediaType == "text/html":
+		return indentMarkup(body, true), extension, true
+	}
+
+	return string(body), extension, true
+}
+
+func getHTTPBodyExtension(mediaType string) string {
+	if extension, found := httpBodyExtensions[mediaType]; found {
+		return extension
+	}
+
+	if extensions, err := mime.ExtensionsByType(mediaType); err == nil && len(extensions) > 0 {
+		return strings.TrimPrefix(extensions[0], ".")
+	}
+
+	return "bin"
+}
+
+func isTextMediaType(mediaType, extension string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		mediaType == "application/json" ||
+		mediaType == "application/xml" ||
+		mediaType == "application/javascript" ||
+		mediaType == "application/x-www-form-urlencoded" ||
+		utils.File.IsText(extension)
+}
+
+func indentJSON(body []byte) string {
+	buffer := bytes.Buffer{}
+	if err := json.Indent(&buffer, body, "", "    "); err != nil {
+		return string(body)
+	}
+	return buffer.String()
+}
+
+// indentMarkup indents XML and HTML documents. The body is returned as is when it can't be parsed.
+func indentMarkup(body []byte, html bool) string {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	nextToken := decoder.RawToken
+	if html {
+		decoder.Strict = false
+		decoder.AutoClose = xml.HTMLAutoClose
+		decoder.Entity = xml.HTMLEntity
+		nextToken = decoder.Token
+	}
+
+	tokens := make([]xml.Token, 0)
+	// RawToken doesn't verify that the elements are closed in order
+	open := make([]xml.Name, 0)
+	for {
+		token, err := nextToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return string(body)
+		}
+		switch element := token.(type) {
+		case xml.CharData:
+			if len(strings.TrimSpace(string(element))) == 0 {
+				continue
+			}
+		case xml.StartElement:
+			open = append(open, element.Name)
+		case xml.EndElement:
+			if len(open) == 0 || open[len(open)-1] != element.Name {
+				return string(body)
+			}
+			open = open[:len(open)-1]
+		}
+		tokens = append(tokens, xml.CopyToken(token))
+	}
+	if len(open) > 0 {
+		return string(body)
+	}
+
+	return writeMarkup(tokens)
+}
+
+func writeMarkup(tokens []xml.Token) string {
+	builder := strings.Builder{}
+	depth := 0
+	writeLine := func(line string) {
+		builder.WriteString(strings.Repeat("    ", depth))
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch token := tokens[i].(type) {
+		case xml.StartElement:
+			start := formatStartElement(token)
+			if i+1 < len(tokens) {
+				if _, ok := tokens[i+1].(xml.EndElement); ok {
+					writeLine(start[:len(start)-1] + " />")
+					i++
+					continue
+				}
+			}
+			if i+2 < len(tokens) {
+				text, isText := tokens[i+1].(xml.CharData)
+				end, isEnd := tokens[i+2].(xml.EndElement)
+				if isText && isEnd {
+					writeLine(start + xmlTextEscaper.Replace(strings.TrimSpace(string(text))) + formatEndElement(end))
+					i += 2
+					continue
+				}
+			}
+			writeLine(start)
+			depth++
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+			}
+			writeLine(formatEndElement(token))
+		case xml.CharData:
+			writeLine(xmlTextEscaper.Replace(strings.TrimSpace(string(token))))
+		case xml.Comment:
+			writeLine(fmt.Sprintf("<!--%s-->", token))
+		case xml.ProcInst:
+			writeLine(fmt.Sprintf("<?%s %s?>", token.Target, token.Inst))
+		case xml.Directive:
+			writeLine(fmt.Sprintf("<!%s>", token))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func formatStartElement(element xml.StartElement) string {
+	builder := strings.Builder{}
+	builder.WriteString("<")
+	builder.WriteString(formatXMLName(element.Name))
+	for _, attribute := range element.Attr {
+		builder.WriteString(fmt.Sprintf(` %s="%s"`, formatXMLName(attribute.Name), xmlAttributeEscaper.Replace(attribute.Value)))
+	}
+	builder.WriteString(">")
+	return builder.String()
+}
+
+func formatEndElement(element xml.EndElement) string {
+	return fmt.Sprintf("</%s>", formatXMLName(element.Name))
+}
+
+func formatXMLName(name xml.Name) string {
+	if len(name.Space) == 0 {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
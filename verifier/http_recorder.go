@@ -0,0 +1,218 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const httpCallsName = "httpCalls"
+
+// HTTPRecorder an http.RoundTripper that records the requests and responses sent through the underlying transport.
+// Use AppendHTTPCalls to verify the recorded calls with the target.
+type HTTPRecorder struct {
+	transport http.RoundTripper
+	mutex     sync.Mutex
+	calls     []*recordedCall
+}
+
+type recordedCall struct {
+	request     *http.Request
+	requestBody []byte
+	response    *http.Response
+	body        []byte
+	err         error
+}
+
+// HTTPCall a recorded request and its response
+type HTTPCall struct {
+	Request  HTTPRequestRecord   `json:"request"`
+	Response *HTTPResponseRecord `json:"response,omitempty"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// HTTPRequestRecord a recorded request. JSON bodies are parsed, and text bodies are kept as strings.
+type HTTPRequestRecord struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// HTTPResponseRecord a recorded response. JSON bodies are parsed, and text bodies are kept as strings.
+type HTTPResponseRecord struct {
+	Status  string            `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// NewHTTPRecorder creates a recorder that sends the requests with the transport, or with http.DefaultTransport when nil
+func NewHTTPRecorder(transport http.RoundTripper) *HTTPRecorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &HTTPRecorder{
+		transport: transport,
+		calls:     make([]*recordedCall, 0),
+	}
+}
+
+// Client returns an http.Client that records its calls
+func (r *HTTPRecorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip sends the request with the underlying transport and records the request and the response.
+// The bodies are buffered, so they can still be read by the caller.
+func (r *HTTPRecorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	call := &recordedCall{request: request}
+
+	// the calls are recorded in the order they were sent
+	r.mutex.Lock()
+	r.calls = append(r.calls, call)
+	r.mutex.Unlock()
+
+	if request.Body != nil && request.Body != http.NoBody {
+		body, err := ioutil.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		call.requestBody = body
+		request = request.Clone(request.Context())
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	response, err := r.transport.RoundTrip(request)
+	if err != nil {
+		r.record(call, nil, nil, err)
+		return nil, err
+	}
+
+	var body []byte
+	if response.Body != nil {
+		body, err = ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			r.record(call, nil, nil, err)
+			return nil, err
+		}
+		response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	r.record(call, response, body, nil)
+	return response, nil
+}
+
+func (r *HTTPRecorder) record(call *recordedCall, response *http.Response, body []byte, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	call.response = response
+	call.body = body
+	call.err = err
+}
+
+// Calls returns the recorded calls. The values of the default scrubbed headers are scrubbed.
+func (r *HTTPRecorder) Calls() []HTTPCall {
+	return r.getCalls(newScrubbedHTTPHeaders())
+}
+
+// Reset removes the recorded calls
+func (r *HTTPRecorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.calls = make([]*recordedCall, 0)
+}
+
+func (r *HTTPRecorder) getCalls(scrubbed map[string]bool) []HTTPCall {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	calls := make([]HTTPCall, 0, len(r.calls))
+	for _, call := range r.calls {
+		calls = append(calls, call.toHTTPCall(scrubbed))
+	}
+	return calls
+}
+
+func (c *recordedCall) toHTTPCall(scrubbed map[string]bool) HTTPCall {
+	result := HTTPCall{
+		Request: HTTPRequestRecord{
+			Method:  c.request.Method,
+			URL:     c.request.URL.String(),
+			Headers: getRecordedHeaders(c.request.Header, scrubbed),
+			Body:    getRecordedBody(c.request.Header.Get("Content-Type"), c.requestBody),
+		},
+	}
+
+	if c.err != nil {
+		result.Error = c.err.Error()
+	}
+
+	if c.response != nil {
+		result.Response = &HTTPResponseRecord{
+			Status:  getStatusLine(c.response),
+			Headers: getRecordedHeaders(c.response.Header, scrubbed),
+			Body:    getRecordedBody(c.response.Header.Get("Content-Type"), c.body),
+		}
+	}
+
+	return result
+}
+
+func getRecordedHeaders(header http.Header, scrubbed map[string]bool) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for name, values := range header {
+		scrubbedValues := make([]string, 0, len(values))
+		for _, value := range values {
+			scrubbedValues = append(scrubbedValues, scrubHTTPHeader(name, value, scrubbed))
+		}
+		headers[name] = strings.Join(scrubbedValues, ", ")
+	}
+	return headers
+}
+
+// getRecordedBody parses JSON bodies so they are serialized and scrubbed with the target.
+// Binary bodies are replaced with their size.
+func getRecordedBody(contentType string, body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	text, extension, isText := formatHTTPBody(contentType, body)
+	if !isText {
+		return fmt.Sprintf("{%d bytes of %s}", len(body), extension)
+	}
+
+	var parsed interface{}
+	if json.Unmarshal(body, &parsed) == nil {
+		switch parsed.(type) {
+		case map[string]interface{}, []interface{}:
+			return parsed
+		}
+	}
+
+	return text
+}
+
+// AppendHTTPCalls appends the calls recorded by the recorder to the target, in a section named `httpCalls`.
+// The headers are scrubbed like VerifyHTTPResponse.
+func AppendHTTPCalls(recorder *HTTPRecorder) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.jsonAppender = append(s.jsonAppender, func() *toAppend {
+			return &toAppend{
+				Name: httpCallsName,
+				Data: recorder.getCalls(s.scrubbedHTTPHeaders),
+			}
+		})
+	}
+}
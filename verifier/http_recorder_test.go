@@ -0,0 +1,61 @@
+package verifier
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPRecorder_RecordsCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write(append([]byte("echo: "), body...))
+	}))
+	defer server.Close()
+
+	recorder := NewHTTPRecorder(nil)
+	response, err := recorder.Client().Post(server.URL+"/echo", "text/plain", bytes.NewBufferString("hello"))
+	if err != nil {
+		t.Fatalf("Should send the request: %s", err)
+	}
+
+	body, _ := ioutil.ReadAll(response.Body)
+	if string(body) != "echo: hello" {
+		t.Fatalf("Should still be able to read the response body: %s", body)
+	}
+
+	calls := recorder.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("Should record one call, got %d", len(calls))
+	}
+	if calls[0].Request.Method != http.MethodPost || !strings.HasSuffix(calls[0].Request.URL, "/echo") {
+		t.Fatalf("Should record the request: %+v", calls[0].Request)
+	}
+	if calls[0].Request.Body != "hello" || calls[0].Response.Body != "echo: hello" {
+		t.Fatalf("Should record the bodies: %+v", calls[0])
+	}
+	if calls[0].Response.Headers["Content-Length"] != scrubbedValue {
+		t.Fatalf("Should scrub the volatile headers: %+v", calls[0].Response.Headers)
+	}
+
+	recorder.Reset()
+	if len(recorder.Calls()) != 0 {
+		t.Fatalf("Should remove the recorded calls")
+	}
+}
+
+func TestGetRecordedBody(t *testing.T) {
+	if body, ok := getRecordedBody("application/json", []byte(`{"a":1}`)).(map[string]interface{}); !ok || body["a"] != 1.0 {
+		t.Fatalf("Should parse JSON bodies: %v", body)
+	}
+	if body := getRecordedBody("image/png", []byte{0x89, 0x50}); body != "{2 bytes of png}" {
+		t.Fatalf("Should describe binary bodies: %v", body)
+	}
+	if body := getRecordedBody("text/plain", nil); body != nil {
+		t.Fatalf("Should omit empty bodies: %v", body)
+	}
+}
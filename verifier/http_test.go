@@ -0,0 +1,58 @@
+package verifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScrubCookie(t *testing.T) {
+	scrubbed := scrubCookie("id=a3fWa; Expires=Thu, 21 Oct 2021 07:28:00 GMT; Secure; Max-Age=60")
+	if scrubbed != "id={Scrubbed}; Expires={Scrubbed}; Secure; Max-Age=60" {
+		t.Fatalf("Should scrub the value and the expiry date: %s", scrubbed)
+	}
+}
+
+func TestScrubHTTPHeaders(t *testing.T) {
+	s := newSettings(t)
+	ScrubHTTPHeaders("x-trace")(s)
+	DontScrubHTTPHeaders("date")(s)
+
+	builder := strings.Builder{}
+	writeHTTPHeaders(&builder, map[string][]string{
+		"X-Trace": {"abc"},
+		"Date":    {"Mon, 02 Jan 2006 15:04:05 GMT"},
+		"Accept":  {"text/plain", "application/json"},
+	}, s.scrubbedHTTPHeaders)
+
+	expected := "Accept: text/plain\nAccept: application/json\nDate: Mon, 02 Jan 2006 15:04:05 GMT\nX-Trace: {Scrubbed}\n"
+	if builder.String() != expected {
+		t.Fatalf("Should write the sorted and scrubbed headers:\n%s", builder.String())
+	}
+}
+
+func TestFormatHTTPBody(t *testing.T) {
+	text, _, isText := formatHTTPBody("application/json", []byte(`{"invalid"`))
+	if !isText || text != `{"invalid"` {
+		t.Fatalf("Should keep invalid JSON as is: %s", text)
+	}
+
+	text, _, isText = formatHTTPBody("application/xml", []byte(`<a><b></a>`))
+	if !isText || text != `<a><b></a>` {
+		t.Fatalf("Should keep invalid XML as is: %s", text)
+	}
+
+	_, extension, isText := formatHTTPBody("text/plain", []byte{0xff, 0xfe})
+	if isText || extension != "bin" {
+		t.Fatalf("Should verify invalid text as binary, got %s", extension)
+	}
+
+	_, extension, isText = formatHTTPBody("image/jpeg", []byte{0xff, 0xd8})
+	if isText || extension != "jpg" {
+		t.Fatalf("Should use the extension of the content type, got %s", extension)
+	}
+
+	text, _, isText = formatHTTPBody("", []byte("plain text"))
+	if !isText || text != "plain text" {
+		t.Fatalf("Should detect the content type: %s", text)
+	}
+}
@@ -0,0 +1,143 @@
+package verifier
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+var imageExtensions = []string{"png", "jpg", "jpeg", "gif"}
+
+var diffPixelColor = color.NRGBA{R: 255, A: 255}
+
+// ImageCompareOptions options for the perceptual comparison of images
+type ImageCompareOptions struct {
+	// Tolerance the maximum difference of a single color channel (0-255) for two pixels to be considered equal
+	Tolerance uint8
+	// MaxDifferingPixels the maximum percentage (0-100) of differing pixels for two images to be considered equal
+	MaxDifferingPixels float64
+}
+
+// NewImageComparer creates a StreamComparerFunc that decodes png, jpg and gif images and compares them pixel by pixel.
+// When the images differ a png highlighting the differing pixels is attached to the CompareResult.
+func NewImageComparer(options ImageCompareOptions) StreamComparerFunc {
+	return func(received, verified []byte) CompareResult {
+		return compareImages(received, verified, options)
+	}
+}
+
+// UseImageComparer compare png, jpg, jpeg and gif files using the perceptual image comparer
+func UseImageComparer(options ImageCompareOptions) VerifyConfigure {
+	return func(s *verifySettings) {
+		comparer := NewImageComparer(options)
+		for _, extension := range imageExtensions {
+			s.streamComparers[extension] = comparer
+		}
+	}
+}
+
+func compareImages(received, verified []byte, options ImageCompareOptions) CompareResult {
+	receivedImage, err := decodeImage(received)
+	if err != nil {
+		return CompareResult{Message: fmt.Sprintf("failed to decode the received image: %s", err)}
+	}
+
+	verifiedImage, err := decodeImage(verified)
+	if err != nil {
+		return CompareResult{Message: fmt.Sprintf("failed to decode the verified image: %s", err)}
+	}
+
+	receivedBounds := receivedImage.Bounds()
+	verifiedBounds := verifiedImage.Bounds()
+	if receivedBounds.Dx() != verifiedBounds.Dx() || receivedBounds.Dy() != verifiedBounds.Dy() {
+		return CompareResult{
+			Message: fmt.Sprintf("image sizes differ. Received: %dx%d, Verified: %dx%d",
+				receivedBounds.Dx(), receivedBounds.Dy(), verifiedBounds.Dx(), verifiedBounds.Dy()),
+		}
+	}
+
+	diff := image.NewNRGBA(image.Rect(0, 0, verifiedBounds.Dx(), verifiedBounds.Dy()))
+	differing := 0
+	for y := 0; y < verifiedBounds.Dy(); y++ {
+		for x := 0; x < verifiedBounds.Dx(); x++ {
+			receivedPixel := receivedImage.At(receivedBounds.Min.X+x, receivedBounds.Min.Y+y)
+			verifiedPixel := verifiedImage.At(verifiedBounds.Min.X+x, verifiedBounds.Min.Y+y)
+			if pixelsEqual(receivedPixel, verifiedPixel, options.Tolerance) {
+				diff.Set(x, y, fadePixel(verifiedPixel))
+				continue
+			}
+			differing++
+			diff.Set(x, y, diffPixelColor)
+		}
+	}
+
+	total := verifiedBounds.Dx() * verifiedBounds.Dy()
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(differing) * 100 / float64(total)
+	}
+
+	message := fmt.Sprintf("%.2f%% of the pixels differ (%d of %d). Allowed: %.2f%%",
+		ratio, differing, total, options.MaxDifferingPixels)
+
+	if differing == 0 || ratio <= options.MaxDifferingPixels {
+		return CompareResult{IsEqual: true, Message: message}
+	}
+
+	buffer := bytes.Buffer{}
+	if err := png.Encode(&buffer, diff); err != nil {
+		return CompareResult{Message: message}
+	}
+
+	return CompareResult{
+		Message:       message,
+		Diff:          buffer.Bytes(),
+		DiffExtension: "png",
+	}
+}
+
+func decodeImage(data []byte) (image.Image, error) {
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bytes.NewReader(data)
+	switch format {
+	case "png":
+		return png.Decode(reader)
+	case "jpeg":
+		return jpeg.Decode(reader)
+	case "gif":
+		return gif.Decode(reader)
+	}
+
+	return nil, fmt.Errorf("unsupported image format: %s", format)
+}
+
+func pixelsEqual(received, verified color.Color, tolerance uint8) bool {
+	first := color.NRGBAModel.Convert(received).(color.NRGBA)
+	second := color.NRGBAModel.Convert(verified).(color.NRGBA)
+
+	return channelEqual(first.R, second.R, tolerance) &&
+		channelEqual(first.G, second.G, tolerance) &&
+		channelEqual(first.B, second.B, tolerance) &&
+		channelEqual(first.A, second.A, tolerance)
+}
+
+func channelEqual(first, second, tolerance uint8) bool {
+	if first > second {
+		return first-second <= tolerance
+	}
+	return second-first <= tolerance
+}
+
+// fadePixel converts the pixel to a light gray, so the differing pixels stand out in the diff image
+func fadePixel(pixel color.Color) color.Color {
+	gray := color.GrayModel.Convert(pixel).(color.Gray)
+	return color.NRGBA{R: 128 + gray.Y/2, G: 128 + gray.Y/2, B: 128 + gray.Y/2, A: 255}
+}
@@ -0,0 +1,130 @@
+package verifier
+
+import (
+	"bytes"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"image"
+	"image/color"
+	"image/png"
+	"path"
+	"strings"
+	"testing"
+)
+
+func createTestImage(t *testing.T, width, height int, changed map[image.Point]color.NRGBA) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: 10, G: 120, B: 200, A: 255})
+		}
+	}
+	for point, c := range changed {
+		img.Set(point.X, point.Y, c)
+	}
+
+	buffer := bytes.Buffer{}
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatalf("failed to encode the image: %s", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestImageComparer_Tolerance(t *testing.T) {
+	verified := createTestImage(t, 10, 10, nil)
+	received := createTestImage(t, 10, 10, map[image.Point]color.NRGBA{
+		{X: 1, Y: 1}: {R: 12, G: 118, B: 200, A: 255},
+	})
+
+	result := NewImageComparer(ImageCompareOptions{Tolerance: 2})(received, verified)
+	if !result.IsEqual {
+		t.Fatalf("should be equal within the tolerance: %s", result.Message)
+	}
+
+	result = NewImageComparer(ImageCompareOptions{Tolerance: 1})(received, verified)
+	if result.IsEqual {
+		t.Fatalf("should not be equal outside the tolerance")
+	}
+	if !strings.Contains(result.Message, "1.00% of the pixels differ (1 of 100)") {
+		t.Fatalf("should report the mismatch ratio: %s", result.Message)
+	}
+	if result.DiffExtension != "png" || len(result.Diff) == 0 {
+		t.Fatalf("should attach a diff image")
+	}
+}
+
+func TestImageComparer_MaxDifferingPixels(t *testing.T) {
+	verified := createTestImage(t, 10, 10, nil)
+	received := createTestImage(t, 10, 10, map[image.Point]color.NRGBA{
+		{X: 1, Y: 1}: {R: 255, A: 255},
+		{X: 2, Y: 2}: {R: 255, A: 255},
+	})
+
+	result := NewImageComparer(ImageCompareOptions{MaxDifferingPixels: 2})(received, verified)
+	if !result.IsEqual {
+		t.Fatalf("should be equal when the ratio is allowed: %s", result.Message)
+	}
+
+	result = NewImageComparer(ImageCompareOptions{MaxDifferingPixels: 1.5})(received, verified)
+	if result.IsEqual {
+		t.Fatalf("should not be equal when the ratio exceeds the maximum")
+	}
+}
+
+func TestImageComparer_DifferentSizes(t *testing.T) {
+	verified := createTestImage(t, 10, 10, nil)
+	received := createTestImage(t, 10, 12, nil)
+
+	result := NewImageComparer(ImageCompareOptions{})(received, verified)
+	if result.IsEqual {
+		t.Fatalf("should not be equal")
+	}
+	if !strings.Contains(result.Message, "Received: 10x12, Verified: 10x10") {
+		t.Fatalf("should report the sizes: %s", result.Message)
+	}
+}
+
+func TestImageComparer_InvalidImage(t *testing.T) {
+	verified := createTestImage(t, 10, 10, nil)
+
+	result := NewImageComparer(ImageCompareOptions{})([]byte("not an image"), verified)
+	if result.IsEqual {
+		t.Fatalf("should not be equal")
+	}
+	if !strings.Contains(result.Message, "failed to decode the received image") {
+		t.Fatalf("should report the decoding failure: %s", result.Message)
+	}
+}
+
+func TestImageComparer_WritesDiffFile(t *testing.T) {
+	dir := t.TempDir()
+	file := newFilePair("png", path.Join(dir, "image"))
+	utils.File.WriteStream(file.VerifiedPath, createTestImage(t, 4, 4, nil))
+
+	s := newSettings(t)
+	UseImageComparer(ImageCompareOptions{})(s)
+
+	received := createTestImage(t, 4, 4, map[image.Point]color.NRGBA{
+		{X: 0, Y: 0}: {R: 255, A: 255},
+	})
+
+	result := comparer.Streams(file, received, s)
+	if result.Equality != FileNotEqual {
+		t.Fatalf("should not be equal")
+	}
+
+	diffPath := path.Join(dir, "image.diff.png")
+	if !utils.File.Exists(diffPath) {
+		t.Fatalf("diff image should have been written next to the received file")
+	}
+
+	diff, err := png.Decode(bytes.NewReader(utils.File.ReadFile(diffPath)))
+	if err != nil {
+		t.Fatalf("diff should be a png image: %s", err)
+	}
+	if color.NRGBAModel.Convert(diff.At(0, 0)) != diffPixelColor {
+		t.Fatalf("differing pixel should be highlighted")
+	}
+	if color.NRGBAModel.Convert(diff.At(1, 1)) == diffPixelColor {
+		t.Fatalf("equal pixel should not be highlighted")
+	}
+}
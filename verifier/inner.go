@@ -3,6 +3,7 @@ package verifier
 import (
 	"encoding"
 	"fmt"
+	"github.com/VerifyTests/Verify.Go/internal/naming"
 	"github.com/VerifyTests/Verify.Go/utils"
 	"github.com/google/uuid"
 	"github.com/modern-go/reflect2"
@@ -11,9 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
 	"unicode"
 	"unicode/utf8"
@@ -26,8 +25,6 @@ const (
 )
 
 var emptyTargets = make([]Target, 0)
-var prefixList = make([]string, 0)
-var locker = &sync.Mutex{}
 
 type innerVerifier struct {
 	testing             testingT
@@ -37,54 +34,74 @@ type innerVerifier struct {
 	getFileNames        getFileNamesFunc
 	getIndexedFileNames getIndexedFileNamesFunc
 	outputDirectory     string
+	receivedDirectory   string
 	verifiedFiles       []string
 	receivedFiles       []string
+	diffFiles           []string
 }
 
 func createInnerVerifier(t testingT, settings *verifySettings) *innerVerifier {
-	uniqueness := newNamer(settings).getUniqueness()
-	fileName, directory := defaultFileConvention(t, settings, uniqueness)
-	sourceFileDirectory := filepath.Dir(fileName)
-
-	if len(directory) == 0 {
-		directory = sourceFileDirectory
-	} else {
-		directory = path.Join(directory, sourceFileDirectory)
-		if !utils.File.Exists(directory) {
-			err := utils.File.CreateDirectory(directory)
-			if err != nil {
-				log.Fatalf("Failed to delete %s", directory)
-			}
+	fileName, directory := newNamer(settings).getFilePrefix(t)
+
+	//file conventions can place the files in sub-directories
+	fileName = filepath.ToSlash(fileName)
+	directory = path.Join(filepath.ToSlash(directory), path.Dir(fileName))
+	fileName = path.Base(fileName)
+
+	if !utils.File.Exists(directory) {
+		err := utils.File.CreateDirectory(directory)
+		if err != nil {
+			log.Fatalf("Failed to create %s", directory)
 		}
 	}
 
+	if !settings.disableRequireUniquePrefix {
+		number := reservePrefix(t, path.Join(directory, fileName))
+		fileName = getNumberedFileName(fileName, number)
+	}
+
 	filePathPrefix := path.Join(directory, fileName)
-	validatePrefix(filePathPrefix)
+	trackVerification(directory, fileName)
 
 	pattern := fmt.Sprintf("%s.*.*", fileName)
 	files, _ := fileMatch(directory, pattern)
 
+	receivedDirectory := getReceivedDirectory(settings, directory)
+	receivedFiles := files
+	if receivedDirectory != directory {
+		if err := utils.File.CreateDirectory(receivedDirectory); err != nil {
+			log.Fatalf("Failed to create %s", receivedDirectory)
+		}
+		receivedFiles, _ = fileMatch(receivedDirectory, pattern)
+	}
+
 	verifier := &innerVerifier{
 		scrubber:            settings.scrubber,
 		testing:             t,
 		outputDirectory:     directory,
+		receivedDirectory:   receivedDirectory,
 		settings:            settings,
-		verifiedFiles:       findMatchingFiles(files, fileName, ".verified"),
-		receivedFiles:       findMatchingFiles(files, fileName, ".received"),
-		getFileNames:        getFileNamePair(filePathPrefix),
-		getIndexedFileNames: getIndexFileNamePair(filePathPrefix),
+		verifiedFiles:       naming.FindMatchingFiles(files, fileName, naming.Verified),
+		receivedFiles:       naming.FindMatchingFiles(receivedFiles, fileName, naming.Received),
+		diffFiles:           naming.FindMatchingFiles(receivedFiles, fileName, naming.Diff),
+		getFileNames:        getFileNamePair(filePathPrefix, path.Join(receivedDirectory, fileName)),
+		getIndexedFileNames: getIndexFileNamePair(filePathPrefix, path.Join(receivedDirectory, fileName)),
 	}
 
 	for _, f := range verifier.receivedFiles {
 		utils.File.Delete(f)
 	}
 
+	for _, f := range verifier.diffFiles {
+		utils.File.Delete(f)
+	}
+
 	settings.runBeforeVerify()
 
 	return verifier
 }
 
-func (v *innerVerifier) verifyInner(data interface{}, cleanup CleanupFunc, targets []Target) {
+func (v *innerVerifier) verifyInner(data interface{}, cleanup CleanupFunc, targets []Target) *VerificationError {
 	if builder, extension, found := v.tryGetTargetBuilder(data); found {
 		v.scrubber.Apply(extension, builder, v.settings)
 
@@ -95,7 +112,7 @@ func (v *innerVerifier) verifyInner(data interface{}, cleanup CleanupFunc, targe
 
 	targets = append(targets, v.settings.getFileAppenders()...)
 
-	engine := newEngine(v.testing, v.outputDirectory, v.settings, v.verifiedFiles, v.getFileNames, v.getIndexedFileNames)
+	engine := newEngine(v.testing, v.outputDirectory, v.receivedDirectory, v.settings, v.verifiedFiles, v.getFileNames, v.getIndexedFileNames)
 
 	engine.handleResults(targets)
 
@@ -103,7 +120,7 @@ func (v *innerVerifier) verifyInner(data interface{}, cleanup CleanupFunc, targe
 		cleanup()
 	}
 
-	engine.throwIfRequired()
+	return engine.getVerificationError()
 }
 
 func (v *innerVerifier) tryGetTargetBuilder(root interface{}) (builder *strings.Builder, extension string, found bool) {
@@ -118,11 +135,7 @@ func (v *innerVerifier) tryGetTargetBuilder(root interface{}) (builder *strings.
 			return
 		}
 
-		extension = textExtension
-		if v.settings.strictJSON {
-			extension = jsonExtension
-		}
-
+		extension = v.settings.serializedExtension()
 		builder = asJSON(root, appenders, v.settings)
 		found = true
 		return
@@ -139,11 +152,7 @@ func (v *innerVerifier) tryGetTargetBuilder(root interface{}) (builder *strings.
 		}
 	}
 
-	extension = textExtension
-	if v.settings.strictJSON {
-		extension = jsonExtension
-	}
-
+	extension = v.settings.serializedExtension()
 	builder = asJSON(root, appenders, v.settings)
 	found = true
 	return
@@ -212,57 +221,27 @@ func (v *innerVerifier) tryGetPrimitiveToString(target interface{}) (asStringRes
 	return asStringResult{}, false
 }
 
-func defaultFileConvention(t testingT, settings *verifySettings, uniqueness string) (string, string) {
-	testName, sourceFile, _ := testCallerInfo()
-	testNameParts := strings.Split(t.Name(), "/")
-	name := getTestCaseName(testNameParts, testName, settings.testCase)
-	sourceFileWithoutExt := utils.File.GetFileNameWithoutExtension(sourceFile)
-
-	if len(settings.directory) == 0 {
-		settings.directory = filepath.Dir(sourceFile)
-	}
-
-	if len(uniqueness) > 0 {
-		return fmt.Sprintf("%s.%s.%s", sourceFileWithoutExt, name, uniqueness), settings.directory
-	}
-
-	return fmt.Sprintf("%s.%s", sourceFileWithoutExt, name), settings.directory
-}
-
-func getFileNamePair(filePathPrefix string) getFileNamesFunc {
+func getFileNamePair(filePathPrefix, receivedPathPrefix string) getFileNamesFunc {
 	return func(extension string) FilePair {
-		return newFilePair(extension, filePathPrefix)
+		return newSeparateFilePair(extension, filePathPrefix, receivedPathPrefix)
 	}
 }
 
-func getIndexFileNamePair(filePathPrefix string) getIndexedFileNamesFunc {
+func getIndexFileNamePair(filePathPrefix, receivedPathPrefix string) getIndexedFileNamesFunc {
 	return func(extension string, index int) FilePair {
-		return newFilePair(extension, fmt.Sprintf("%s.%02d", filePathPrefix, index))
+		return newSeparateFilePair(extension,
+			fmt.Sprintf("%s.%02d", filePathPrefix, index),
+			fmt.Sprintf("%s.%02d", receivedPathPrefix, index))
 	}
 }
 
-func getTestCaseName(parts []string, testName, caseName string) string {
-	test := removeTestPrefix(testName)
-
+func getTestCaseName(parts []string, caseName string) string {
 	//test case is not specified, but can be
 	//determined, based on the table test-case name
 	if len(parts) == 2 && len(caseName) == 0 {
 		caseName = parts[1]
 	}
-
-	if len(test) == 0 && len(caseName) > 0 {
-		return caseName
-	}
-
-	if len(caseName) == 0 && len(test) > 0 {
-		return test
-	}
-
-	if len(test) > 0 && len(caseName) > 0 {
-		return fmt.Sprintf("%s.%s", test, caseName)
-	}
-
-	panic("Test name can't be determined. Provide the name via settings.TestCase method.")
+	return caseName
 }
 
 func removeTestPrefix(testName string) string {
@@ -273,7 +252,7 @@ func removeTestPrefix(testName string) string {
 	return testName
 }
 
-func testCallerInfo() (functionName string, filePath string, line int) {
+func testCallerInfo() (functionName string, packageName string, filePath string, line int) {
 
 	var pc uintptr
 	var ok bool
@@ -319,6 +298,7 @@ func testCallerInfo() (functionName string, filePath string, line int) {
 				isTest(potentialTestName, "Benchmark") ||
 				isTest(potentialTestName, "Example") {
 				functionName = potentialTestName
+				packageName = getPackageName(name)
 				filePath = file
 				return
 			}
@@ -333,6 +313,7 @@ func testCallerInfo() (functionName string, filePath string, line int) {
 				isTest(tableTestName, "Benchmark") ||
 				isTest(tableTestName, "Example") {
 				functionName = tableTestName
+				packageName = getPackageName(name)
 				filePath = file
 				return
 			}
@@ -341,6 +322,15 @@ func testCallerInfo() (functionName string, filePath string, line int) {
 	return
 }
 
+// getPackageName extracts the package name from a fully qualified function name, e.g. `github.com/org/repo/pkg_test.TestName`
+func getPackageName(functionName string) string {
+	name := functionName[strings.LastIndex(functionName, "/")+1:]
+	if index := strings.Index(name, "."); index != -1 {
+		return name[:index]
+	}
+	return name
+}
+
 func isTest(name, prefix string) bool {
 	if !strings.HasPrefix(name, prefix) {
 		return false
@@ -375,45 +365,3 @@ func fileMatch(root, pattern string) ([]string, error) {
 
 	return matches, nil
 }
-
-func findMatchingFiles(files []string, fileNamePrefix string, suffix string) []string {
-	matches := make([]string, 0)
-	for _, f := range files {
-		name := utils.File.GetFileNameWithoutExtension(f)
-		if !strings.HasPrefix(name, fileNamePrefix) {
-			continue
-		}
-
-		if !strings.HasSuffix(name, suffix) {
-			continue
-		}
-
-		prefixRemoved := name[len(fileNamePrefix):]
-		if prefixRemoved == suffix {
-			matches = append(matches, f)
-			continue
-		}
-
-		numberPart := prefixRemoved[1 : len(prefixRemoved)-len(suffix)-1]
-		if _, err := strconv.Atoi(numberPart); err == nil {
-			matches = append(matches, f)
-		}
-	}
-	return matches
-}
-
-func validatePrefix(prefix string) {
-	locker.Lock()
-	defer locker.Unlock()
-
-	for _, f := range prefixList {
-		if f == prefix {
-			panic(fmt.Sprintf("The prefix has already been used: %s.\n"+
-				"This is mostly caused by a conflicting combination of "+
-				"`verifier.UseDirectory()`, `verifier.TestCase(), and `verifier.TestName()`.\n"+
-				"If that's not the case, and having multiple identical prefixes is acceptable, then call `VerifierSettings.DisableRequireUniquePrefix()` "+
-				"to disable this uniqueness validation.", prefix))
-		}
-	}
-	prefixList = append(prefixList, prefix)
-}
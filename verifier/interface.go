@@ -1,9 +1,10 @@
 package verifier
 
 import (
-	"fmt"
+	"github.com/VerifyTests/Verify.Go/internal/naming"
 	"github.com/VerifyTests/Verify.Go/utils"
 	"path"
+	"strings"
 )
 
 type testingT interface {
@@ -42,6 +43,11 @@ const (
 type CompareResult struct {
 	IsEqual bool
 	Message string
+	// Diff optional content that visualizes the differences. When the files are not equal it is
+	// written next to the received file, e.g. `Name.diff.png`
+	Diff []byte
+	// DiffExtension the extension of the Diff content
+	DiffExtension string
 }
 
 // InstanceScrubber a function that scrubs the input target and returns the scrubbed version
@@ -62,8 +68,30 @@ type ReplaceLineFunc func(string) string
 // CleanupFunc cleanup function
 type CleanupFunc func()
 
-// FileConventionFunc provides a unique file and directory for the test
-type FileConventionFunc func(uniqueness string) (fileNamePrefix string, directory string)
+// FileConventionFunc provides a unique file name prefix and directory for the test.
+// The file name prefix can contain sub-directories, relative to the directory.
+type FileConventionFunc func(info FileNameInfo) (fileNamePrefix string, directory string)
+
+// FileNameInfo information about the running test used to name the verified files
+type FileNameInfo struct {
+	// SourceFile the path of the test source file
+	SourceFile string
+	// Directory the directory set via UseDirectory, or the directory of the test source file
+	Directory string
+	// Package the last element of the test package path, e.g. `verifier_test` for external test packages.
+	// It is `command-line-arguments` when the tests are run with a list of files, e.g. `go test ./pkg/*.go`.
+	Package string
+	// TypeName the test source file name without the extension, or the name set via UseTypeName
+	TypeName string
+	// TestName the name of the test function, e.g. `TestSomething`
+	TestName string
+	// MethodName the test name without the `Test` prefix, or the name set via UseMethodName
+	MethodName string
+	// TestCase the name set via TestCase, or the sub-test name of table tests
+	TestCase string
+	// Uniqueness the runtime specific part of the name, e.g. `.linux`
+	Uniqueness string
+}
 
 // FileAppenderFunc returns a target
 type FileAppenderFunc func() *Target
@@ -97,6 +125,24 @@ type FilePair struct {
 	IsText       bool
 }
 
+// VerificationError the result of a failed verification, returned by VerifyE
+type VerificationError struct {
+	TestName          string
+	Directory         string
+	ReceivedDirectory string
+	NewFiles          NewFiles
+	NotEqualFiles     NotEqualFiles
+	EqualFiles        EqualFiles
+	// DeletedFiles the verified files that were not produced by the verification
+	DeletedFiles []string
+	message      string
+}
+
+// Error returns the failure message, the same as reported by Verify
+func (e *VerificationError) Error() string {
+	return e.message
+}
+
 // NewFiles a slice of FilePair that contains new files
 type NewFiles []FilePair
 
@@ -133,10 +179,20 @@ func newStringResult(value string) asStringResult {
 	}
 }
 
+func getDiffPath(file FilePair, extension string) string {
+	prefix := strings.TrimSuffix(file.ReceivedPath, "."+naming.Received+"."+file.Extension)
+	return naming.FilePath(prefix, naming.Diff, extension)
+}
+
 func newFilePair(extension, prefix string) FilePair {
+	return newSeparateFilePair(extension, prefix, prefix)
+}
+
+// newSeparateFilePair creates a FilePair whose received file is stored with a different prefix than the verified file
+func newSeparateFilePair(extension, prefix, receivedPrefix string) FilePair {
 
-	received := fmt.Sprintf("%s.received.%s", prefix, extension)
-	verified := fmt.Sprintf("%s.verified.%s", prefix, extension)
+	received := naming.FilePath(receivedPrefix, naming.Received, extension)
+	verified := naming.FilePath(prefix, naming.Verified, extension)
 
 	return FilePair{
 		Extension:    extension,
@@ -0,0 +1,114 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"github.com/heskandari/jsoner"
+	"github.com/modern-go/reflect2"
+	"reflect"
+	"unsafe"
+)
+
+const (
+	verifyTag       = "verify"
+	verifyTagIgnore = "ignore"
+	verifyTagScrub  = "scrub"
+)
+
+// memberExtension applies the member rules of the settings and the `verify` struct tags to the serialized structs
+type memberExtension struct {
+	jsoner.DummyExtension
+	settings *verifySettings
+}
+
+type scrubbedMemberEncoder struct {
+	encoder jsoner.ValEncoder
+}
+
+func newMemberExtension(settings *verifySettings) *memberExtension {
+	return &memberExtension{
+		settings: settings,
+	}
+}
+
+// UpdateStructDescriptor removes the ignored members, and replaces the encoder of the scrubbed members
+func (e *memberExtension) UpdateStructDescriptor(structDescriptor *jsoner.StructDescriptor) {
+	structType := structDescriptor.Type.Type1()
+	for _, binding := range structDescriptor.Fields {
+		field := binding.Field
+		tag := field.Tag().Get(verifyTag)
+
+		if tag == verifyTagIgnore || e.isIgnored(structType, field) {
+			binding.ToNames = []string{}
+			continue
+		}
+
+		if tag == verifyTagScrub || e.settings.scrubbedMembers[field.Name()] {
+			binding.Encoder = &scrubbedMemberEncoder{encoder: binding.Encoder}
+		}
+	}
+}
+
+func (e *memberExtension) isIgnored(structType reflect.Type, field reflect2.StructField) bool {
+	if members, found := e.settings.ignoredMembers[structType]; found && members[field.Name()] {
+		return true
+	}
+
+	fieldType := field.Type().Type1()
+	if fieldType.Kind() == reflect.Ptr && e.settings.ignoredMemberTypes[fieldType.Elem()] {
+		return true
+	}
+	return e.settings.ignoredMemberTypes[fieldType]
+}
+
+func (e *scrubbedMemberEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return e.encoder.IsEmpty(ptr)
+}
+
+func (e *scrubbedMemberEncoder) Encode(ptr unsafe.Pointer, stream *jsoner.Stream) {
+	stream.WriteString(scrubbedValue)
+}
+
+// IgnoreMember removes the members of the type from the serialized targets. The type is passed as a value,
+// e.g. `IgnoreMember(Person{}, "Dob")`.
+func IgnoreMember(target interface{}, names ...string) VerifyConfigure {
+	structType := getMemberType(target)
+
+	return func(s *verifySettings) {
+		members, found := s.ignoredMembers[structType]
+		if !found {
+			members = make(map[string]bool)
+			s.ignoredMembers[structType] = members
+		}
+		for _, name := range names {
+			members[name] = true
+		}
+	}
+}
+
+// IgnoreMembersWithType removes the members of the type, or of pointers to the type, from the serialized targets.
+// The type is passed as a value, e.g. `IgnoreMembersWithType(AuditInfo{})`.
+func IgnoreMembersWithType(target interface{}) VerifyConfigure {
+	memberType := getMemberType(target)
+	return func(s *verifySettings) {
+		s.ignoredMemberTypes[memberType] = true
+	}
+}
+
+func getMemberType(target interface{}) reflect.Type {
+	utils.Guard.AgainstNil(target)
+
+	memberType := reflect.TypeOf(target)
+	for memberType.Kind() == reflect.Ptr {
+		memberType = memberType.Elem()
+	}
+	return memberType
+}
+
+// ScrubMember replaces the values of the members with the names with `{Scrubbed}`, in the structs of any type
+func ScrubMember(names ...string) VerifyConfigure {
+	return func(s *verifySettings) {
+		for _, name := range names {
+			s.scrubbedMembers[name] = true
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package verifier
+
+import (
+	"strings"
+	"testing"
+)
+
+type memberTarget struct {
+	Name     string
+	Secret   string `json:"secret,omitempty"`
+	Address  *Address
+	Internal string   `verify:"ignore"`
+	Token    string   `verify:"scrub"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+func serializeWithRules(t *testing.T, target interface{}, configure ...VerifyConfigure) string {
+	s := newSettings(t)
+	UseStrictJSON()(s)
+	applyConfigure(s, configure)
+	return newSerializer(s, s.scrubber).Serialize(target)
+}
+
+func TestMemberTags(t *testing.T) {
+	serialized := serializeWithRules(t, memberTarget{Internal: "internal", Token: "token"})
+
+	if strings.Contains(serialized, "Internal") {
+		t.Fatalf("Should ignore the members tagged with ignore:\n%s", serialized)
+	}
+	if !strings.Contains(serialized, `"Token": "{Scrubbed}"`) {
+		t.Fatalf("Should scrub the members tagged with scrub:\n%s", serialized)
+	}
+}
+
+func TestScrubMember_KeepsOmitEmpty(t *testing.T) {
+	serialized := serializeWithRules(t, memberTarget{Name: "name"}, ScrubMember("Tags", "Name"))
+
+	if strings.Contains(serialized, "tags") {
+		t.Fatalf("Should omit the empty scrubbed member:\n%s", serialized)
+	}
+	if !strings.Contains(serialized, `"Name": "{Scrubbed}"`) {
+		t.Fatalf("Should scrub the member:\n%s", serialized)
+	}
+}
+
+func TestIgnoreMembersWithType_Pointers(t *testing.T) {
+	serialized := serializeWithRules(t, memberTarget{Address: &Address{Street: "Main"}}, IgnoreMembersWithType(&Address{}))
+
+	if strings.Contains(serialized, "Address") {
+		t.Fatalf("Should ignore the pointers to the type:\n%s", serialized)
+	}
+}
+
+func TestIgnoreMember_OtherTypes(t *testing.T) {
+	serialized := serializeWithRules(t, memberTarget{Address: &Address{Street: "Main"}}, IgnoreMember(Address{}, "Name", "Country"))
+
+	if !strings.Contains(serialized, `"Name"`) {
+		t.Fatalf("Should only ignore the members of the type:\n%s", serialized)
+	}
+	if strings.Contains(serialized, "Country") {
+		t.Fatalf("Should ignore the member of the type:\n%s", serialized)
+	}
+}
+
+func TestIgnoreMember_Nil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Should not accept a nil type")
+		}
+	}()
+	IgnoreMember(nil, "Name")
+}
@@ -2,10 +2,27 @@ package verifier
 
 import (
 	"fmt"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
 
+// SetDefaultFileConvention sets the file convention for all the verifiers that don't use their own.
+// Passing nil restores the default `<source file>.<test name>.<test case>` convention.
+func SetDefaultFileConvention(fun FileConventionFunc) {
+	updateDefaults(func(d *defaults) {
+		d.fileConvention = fun
+	})
+}
+
+func getGlobalFileConvention() (convention FileConventionFunc) {
+	readDefaults(func(d *defaults) {
+		convention = d.fileConvention
+	})
+	return
+}
+
 type namer struct {
 	architecture    string
 	operatingSystem string
@@ -32,6 +49,81 @@ func (n *namer) getUniqueness() string {
 	return builder.String()
 }
 
+// getFilePrefix returns the file name prefix and the directory of the verified files
+func (n *namer) getFilePrefix(t testingT) (string, string) {
+	info := n.getFileNameInfo(t)
+
+	if len(n.settings.fileName) > 0 {
+		return withUniqueness(n.settings.fileName, info.Uniqueness), info.Directory
+	}
+
+	if n.settings.fileConvention != nil {
+		return n.settings.fileConvention(info)
+	}
+
+	if convention := getGlobalFileConvention(); convention != nil {
+		return convention(info)
+	}
+
+	return defaultFileConvention(info)
+}
+
+func (n *namer) getFileNameInfo(t testingT) FileNameInfo {
+	testName, packageName, sourceFile, _ := testCallerInfo()
+	testNameParts := strings.Split(t.Name(), "/")
+
+	if len(n.settings.directory) == 0 {
+		n.settings.directory = filepath.Dir(sourceFile)
+	}
+
+	typeName := n.settings.typeName
+	if len(typeName) == 0 {
+		typeName = utils.File.GetFileNameWithoutExtension(sourceFile)
+	}
+
+	methodName := n.settings.methodName
+	if len(methodName) == 0 {
+		methodName = removeTestPrefix(testName)
+	}
+
+	return FileNameInfo{
+		SourceFile: sourceFile,
+		Directory:  n.settings.directory,
+		Package:    packageName,
+		TypeName:   typeName,
+		TestName:   testName,
+		MethodName: methodName,
+		TestCase:   getTestCaseName(testNameParts, n.settings.testCase),
+		Uniqueness: n.getUniqueness(),
+	}
+}
+
+// defaultFileConvention names the files as `<source file>.<test name>.<test case>` in the test directory
+func defaultFileConvention(info FileNameInfo) (string, string) {
+	if len(info.MethodName) == 0 && len(info.TestCase) == 0 {
+		panic("Test name can't be determined. Provide the name via settings.TestCase method.")
+	}
+
+	parts := []string{info.TypeName}
+	if len(info.MethodName) > 0 {
+		parts = append(parts, info.MethodName)
+	}
+	if len(info.TestCase) > 0 {
+		parts = append(parts, info.TestCase)
+	}
+
+	return withUniqueness(strings.Join(parts, "."), info.Uniqueness), info.Directory
+}
+
+// withUniqueness appends the uniqueness to the file name prefix as `<prefix>..<uniqueness>`,
+// the naming used by the verified files of the earlier versions
+func withUniqueness(prefix string, uniqueness string) string {
+	if len(uniqueness) == 0 {
+		return prefix
+	}
+	return prefix + "." + uniqueness
+}
+
 func (n *namer) getArchitecture() string {
 	return runtime.GOARCH
 }
@@ -39,3 +39,60 @@ func TestNamerUniqueness(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultFileConvention(t *testing.T) {
+	info := FileNameInfo{
+		Directory:  "dir",
+		TypeName:   "source_test",
+		MethodName: "Something",
+		TestCase:   "case",
+		Uniqueness: ".linux",
+	}
+
+	fileName, directory := defaultFileConvention(info)
+	if fileName != "source_test.Something.case..linux" {
+		t.Fatalf("Should join the name parts, got %s", fileName)
+	}
+	if directory != "dir" {
+		t.Fatalf("Should use the test directory, got %s", directory)
+	}
+
+	info.TestCase = ""
+	if fileName, _ = defaultFileConvention(info); fileName != "source_test.Something..linux" {
+		t.Fatalf("Should skip the empty test case, got %s", fileName)
+	}
+}
+
+func TestFileNameInfo(t *testing.T) {
+	t.Run("case", func(t *testing.T) {
+		info := newNamer(newSettings(t)).getFileNameInfo(t)
+
+		if info.TypeName != "namer_test" {
+			t.Fatalf("Should use the source file name, got %s", info.TypeName)
+		}
+		if info.TestName != "TestFileNameInfo" || info.MethodName != "FileNameInfo" {
+			t.Fatalf("Should find the test name, got %s and %s", info.TestName, info.MethodName)
+		}
+		if info.TestCase != "case" {
+			t.Fatalf("Should use the sub-test as the test case, got %s", info.TestCase)
+		}
+	})
+}
+
+func TestGlobalFileConvention(t *testing.T) {
+	SetDefaultFileConvention(func(info FileNameInfo) (string, string) {
+		return "global." + info.MethodName, "snapshots"
+	})
+	t.Cleanup(ResetDefaults)
+
+	fileName, directory := newNamer(newSettings(t)).getFilePrefix(t)
+	if fileName != "global.GlobalFileConvention" || directory != "snapshots" {
+		t.Fatalf("Should use the global convention, got %s and %s", fileName, directory)
+	}
+
+	settings := newSettings(t)
+	UseFileName("local")(settings)
+	if fileName, _ = newNamer(settings).getFilePrefix(t); fileName != "local" {
+		t.Fatalf("Should prefer the verifier settings, got %s", fileName)
+	}
+}
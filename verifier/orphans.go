@@ -0,0 +1,291 @@
+package verifier
+
+import (
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/internal/naming"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TestRunner runs the tests of a package, usually the *testing.M passed to TestMain
+type TestRunner interface {
+	Run() int
+}
+
+// OrphanOptions options for the detection of the orphaned verified files
+type OrphanOptions struct {
+	// Delete deletes the orphaned verified files, instead of only reporting them
+	Delete bool
+	// FailOnOrphans fails the test run when orphaned verified files are found and not deleted
+	FailOnOrphans bool
+	// Ignore globs of the verified file names that are never reported, e.g. files named with UseMethodName
+	Ignore []string
+}
+
+// orphanReport the output of the orphaned verified files report, kept out of stdout where the test output is written
+var orphanReport io.Writer = os.Stderr
+
+var trackerLocker = &sync.Mutex{}
+var activeTracker *orphanTracker
+
+// orphanTracker records the verified file prefixes and the test source files of a test run
+type orphanTracker struct {
+	locker      sync.Mutex
+	prefixes    map[string]bool
+	sourceFiles map[string]bool
+	directories map[string]bool
+}
+
+// RunWithOrphanDetection runs the tests and reports the verified files that belong to the test source files of the
+// package, but whose test function no longer exists. Nothing is reported when the tests fail.
+// Call it from `TestMain`:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(verifier.RunWithOrphanDetection(m, verifier.OrphanOptions{}))
+//	}
+func RunWithOrphanDetection(m TestRunner, options OrphanOptions) int {
+	tracker := newOrphanTracker()
+
+	trackerLocker.Lock()
+	activeTracker = tracker
+	trackerLocker.Unlock()
+
+	code := m.Run()
+
+	trackerLocker.Lock()
+	activeTracker = nil
+	trackerLocker.Unlock()
+
+	if code != 0 {
+		return code
+	}
+
+	orphans := tracker.findOrphans(options)
+	if len(orphans) == 0 {
+		return code
+	}
+
+	if options.Delete {
+		for _, orphan := range orphans {
+			utils.File.Delete(orphan)
+			fmt.Fprintf(orphanReport, "Deleted orphaned verified file: %s\n", orphan)
+		}
+		return code
+	}
+
+	fmt.Fprintf(orphanReport, "Found %d orphaned verified file(s):\n", len(orphans))
+	for _, orphan := range orphans {
+		fmt.Fprintf(orphanReport, "  - %s\n", orphan)
+	}
+
+	if options.FailOnOrphans {
+		fmt.Fprintln(orphanReport, "FAIL: orphaned verified files were found")
+		return 1
+	}
+	return code
+}
+
+func newOrphanTracker() *orphanTracker {
+	return &orphanTracker{
+		prefixes:    make(map[string]bool),
+		sourceFiles: make(map[string]bool),
+		directories: make(map[string]bool),
+	}
+}
+
+// trackVerification records the file prefix of a verification, when the orphan detection is running
+func trackVerification(directory, fileName string) {
+	trackerLocker.Lock()
+	tracker := activeTracker
+	trackerLocker.Unlock()
+
+	if tracker == nil {
+		return
+	}
+
+	_, _, sourceFile, _ := testCallerInfo()
+	tracker.record(sourceFile, directory, fileName)
+}
+
+func (o *orphanTracker) record(sourceFile, directory, fileName string) {
+	o.locker.Lock()
+	defer o.locker.Unlock()
+
+	if absolute, err := filepath.Abs(directory); err == nil {
+		directory = absolute
+	}
+
+	o.directories[directory] = true
+	o.prefixes[filepath.Join(directory, fileName)] = true
+	if len(sourceFile) > 0 {
+		o.sourceFiles[filepath.Clean(sourceFile)] = true
+	}
+}
+
+// findOrphans finds the verified files in the directories used by the tests that follow the default naming of a
+// test source file, but whose test function doesn't exist anymore.
+// Tests that exist but didn't run, because of `-run` or skips, keep their files.
+func (o *orphanTracker) findOrphans(options OrphanOptions) []string {
+	o.locker.Lock()
+	defer o.locker.Unlock()
+
+	testFunctions := o.findTestFunctions()
+	orphans := make([]string, 0)
+
+	for directory := range o.directories {
+		files, err := ioutil.ReadDir(directory)
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			if file.IsDir() || !strings.Contains(file.Name(), ".verified.") {
+				continue
+			}
+
+			filePath := filepath.Join(directory, file.Name())
+			if o.isClaimed(filePath) || isIgnored(file.Name(), options.Ignore) {
+				continue
+			}
+
+			if isOrphan(file.Name(), testFunctions) {
+				orphans = append(orphans, filePath)
+			}
+		}
+	}
+
+	sort.Strings(orphans)
+	return orphans
+}
+
+// isClaimed checks if a verification used the file, including its indexed targets
+func (o *orphanTracker) isClaimed(filePath string) bool {
+	directory := filepath.Dir(filePath)
+	for prefix := range o.prefixes {
+		if filepath.Dir(prefix) != directory {
+			continue
+		}
+		if len(naming.FindMatchingFiles([]string{filepath.ToSlash(filePath)}, filepath.Base(prefix), naming.Verified)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// testSource the test functions of a test source file, and the string literals that can name its verified files,
+// e.g. the values passed to UseMethodName, UseFileName, TestCase or t.Run
+type testSource struct {
+	functions map[string]bool
+	literals  map[string]bool
+}
+
+// findTestFunctions finds the test functions of the test source files in the directories of the tracked source files,
+// by the source file name without the extension
+func (o *orphanTracker) findTestFunctions() map[string]*testSource {
+	result := make(map[string]*testSource)
+	sourceDirectories := make(map[string]bool)
+	for sourceFile := range o.sourceFiles {
+		sourceDirectories[filepath.Dir(sourceFile)] = true
+	}
+
+	for directory := range sourceDirectories {
+		matches, _ := filepath.Glob(filepath.Join(directory, "*_test.go"))
+		for _, sourceFile := range matches {
+			source, err := parseTestSource(sourceFile)
+			if err != nil {
+				continue
+			}
+			result[utils.File.GetFileNameWithoutExtension(sourceFile)] = source
+		}
+	}
+	return result
+}
+
+func parseTestSource(sourceFile string) (*testSource, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), sourceFile, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &testSource{
+		functions: make(map[string]bool),
+		literals:  make(map[string]bool),
+	}
+	for _, declaration := range file.Decls {
+		if function, ok := declaration.(*ast.FuncDecl); ok {
+			source.functions[removeTestPrefix(function.Name.Name)] = true
+		}
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		literal, ok := node.(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+		value, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			return true
+		}
+		//the sub-test names are rewritten the same way by the testing package
+		for _, segment := range strings.Split(strings.ReplaceAll(value, " ", "_"), ".") {
+			source.literals[segment] = true
+		}
+		return true
+	})
+	return source, nil
+}
+
+// isOrphan checks if the file is named `<source file>.<test name>...` after a known source file, and is provably
+// not used by that source file: no test function has the name, and no string literal of the source file contains it.
+// The `_N` suffix of the repeated verifications of a test is removed from the test name.
+func isOrphan(fileName string, sources map[string]*testSource) bool {
+	for typeName, source := range sources {
+		if !strings.HasPrefix(fileName, typeName+".") {
+			continue
+		}
+
+		methodName := strings.SplitN(fileName[len(typeName)+1:], ".", 2)[0]
+		if methodName == "verified" || len(methodName) == 0 {
+			return false
+		}
+
+		for _, name := range []string{methodName, removeNumberSuffix(methodName)} {
+			if source.functions[name] || source.literals[name] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// removeNumberSuffix removes the `_N` suffix added by getNumberedFileName
+func removeNumberSuffix(name string) string {
+	index := strings.LastIndex(name, "_")
+	if index == -1 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[index+1:]); err != nil {
+		return name
+	}
+	return name[:index]
+}
+
+func isIgnored(fileName string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, fileName); matched {
+			return true
+		}
+	}
+	return false
+}
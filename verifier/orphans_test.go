@@ -0,0 +1,128 @@
+package verifier
+
+import (
+	"bytes"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type testRunnerFunc func() int
+
+func (f testRunnerFunc) Run() int {
+	return f()
+}
+
+func createOrphanTestFiles(t *testing.T) string {
+	dir := t.TempDir()
+	utils.File.WriteText(filepath.Join(dir, "sample_test.go"), `package sample
+
+import "testing"
+
+func TestExisting(t *testing.T) {}
+
+func TestNamed(t *testing.T) {
+	t.Run("sub test", func(t *testing.T) {})
+	_ = "Custom"
+}
+`)
+	for _, name := range []string{
+		"sample_test.Existing.verified.txt",
+		"sample_test.Existing.case.01.verified.txt",
+		"sample_test.Removed.verified.txt",
+		"sample_test.Removed.case.verified.txt",
+		"sample_test.Renamed.verified.txt",
+		"sample_test.Existing_2.verified.txt",
+		"sample_test.Removed_2.verified.txt",
+		"sample_test.Custom.verified.txt",
+		"sample_test.Named.sub_test.verified.txt",
+		"other_test.Something.verified.txt",
+	} {
+		utils.File.WriteText(filepath.Join(dir, name), "verified")
+	}
+	return dir
+}
+
+func TestOrphanTracker_FindOrphans(t *testing.T) {
+	dir := createOrphanTestFiles(t)
+
+	tracker := newOrphanTracker()
+	tracker.record(filepath.Join(dir, "sample_test.go"), dir, "sample_test.Renamed")
+
+	orphans := tracker.findOrphans(OrphanOptions{})
+	if len(orphans) != 3 ||
+		orphans[0] != filepath.Join(dir, "sample_test.Removed.case.verified.txt") ||
+		orphans[1] != filepath.Join(dir, "sample_test.Removed.verified.txt") ||
+		orphans[2] != filepath.Join(dir, "sample_test.Removed_2.verified.txt") {
+		t.Fatalf("Should only report the files of removed tests, got %v", orphans)
+	}
+
+	if orphans = tracker.findOrphans(OrphanOptions{Ignore: []string{"*.Removed.*", "*.Removed_2.*"}}); len(orphans) != 0 {
+		t.Fatalf("Should skip the ignored files, got %v", orphans)
+	}
+}
+
+func TestRunWithOrphanDetection(t *testing.T) {
+	dir := createOrphanTestFiles(t)
+	runner := func(code int) testRunnerFunc {
+		return func() int {
+			activeTracker.record(filepath.Join(dir, "sample_test.go"), dir, "sample_test.Existing")
+			return code
+		}
+	}
+
+	if code := RunWithOrphanDetection(runner(1), OrphanOptions{Delete: true}); code != 1 {
+		t.Fatalf("Should return the test result, got %d", code)
+	}
+	if !utils.File.Exists(filepath.Join(dir, "sample_test.Removed.verified.txt")) {
+		t.Fatalf("Should not delete files when the tests fail")
+	}
+
+	report := &bytes.Buffer{}
+	orphanReport = report
+	defer func() { orphanReport = os.Stderr }()
+
+	if code := RunWithOrphanDetection(runner(0), OrphanOptions{}); code != 0 {
+		t.Fatalf("Should only report the orphans, got %d", code)
+	}
+	if !strings.Contains(report.String(), filepath.Join(dir, "sample_test.Removed.verified.txt")) {
+		t.Fatalf("Should report the orphans, got %s", report.String())
+	}
+
+	if code := RunWithOrphanDetection(runner(0), OrphanOptions{FailOnOrphans: true}); code != 1 {
+		t.Fatalf("Should fail when orphans are found, got %d", code)
+	}
+
+	if code := RunWithOrphanDetection(runner(0), OrphanOptions{Delete: true}); code != 0 {
+		t.Fatalf("Should succeed, got %d", code)
+	}
+	if utils.File.Exists(filepath.Join(dir, "sample_test.Removed.verified.txt")) {
+		t.Fatalf("Should delete the orphaned files")
+	}
+	for _, name := range []string{
+		"sample_test.Existing.case.01.verified.txt",
+		"sample_test.Existing_2.verified.txt",
+		"sample_test.Custom.verified.txt",
+		"sample_test.Named.sub_test.verified.txt",
+	} {
+		if !utils.File.Exists(filepath.Join(dir, name)) {
+			t.Fatalf("Should keep the files of existing tests, deleted %s", name)
+		}
+	}
+}
+
+func TestRemoveNumberSuffix(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Name_2":     "Name",
+		"Name_12":    "Name",
+		"Name":       "Name",
+		"Name_case":  "Name_case",
+		"Snake_name": "Snake_name",
+	} {
+		if actual := removeNumberSuffix(name); actual != expected {
+			t.Fatalf("Expected %s for %s, got %s", expected, name, actual)
+		}
+	}
+}
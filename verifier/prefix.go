@@ -0,0 +1,55 @@
+package verifier
+
+import (
+	"fmt"
+	"sync"
+)
+
+var prefixLocker = &sync.Mutex{}
+var prefixReservations = make(map[string]*prefixReservation)
+
+// prefixReservation keeps track of the test that owns a file prefix and how many times it verified with it
+type prefixReservation struct {
+	owner testingT
+	count int
+}
+
+// reservePrefix reserves the file prefix for the running test and returns the number of the current verification.
+// The reservation is released when the test completes, so reruns (e.g. `go test -count=2`) can use the same prefix.
+func reservePrefix(t testingT, prefix string) int {
+	prefixLocker.Lock()
+	defer prefixLocker.Unlock()
+
+	if reservation, found := prefixReservations[prefix]; found {
+		if reservation.owner != t {
+			panic(fmt.Sprintf("The prefix has already been used by %s: %s.\n"+
+				"This is mostly caused by a conflicting combination of "+
+				"`verifier.UseDirectory()`, `verifier.TestCase()`, and `verifier.TestName()`.\n"+
+				"If that's not the case, and having multiple identical prefixes is acceptable, then use `verifier.DisableRequireUniquePrefix()` "+
+				"to disable this uniqueness validation.", reservation.owner.Name(), prefix))
+		}
+		reservation.count++
+		return reservation.count
+	}
+
+	prefixReservations[prefix] = &prefixReservation{owner: t, count: 1}
+	t.Cleanup(func() {
+		releasePrefix(prefix)
+	})
+	return 1
+}
+
+func releasePrefix(prefix string) {
+	prefixLocker.Lock()
+	defer prefixLocker.Unlock()
+
+	delete(prefixReservations, prefix)
+}
+
+// getNumberedFileName appends the verification number to the file name, when a test verifies more than once
+func getNumberedFileName(fileName string, number int) string {
+	if number <= 1 {
+		return fileName
+	}
+	return fmt.Sprintf("%s_%d", fileName, number)
+}
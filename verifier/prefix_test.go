@@ -0,0 +1,48 @@
+package verifier
+
+import (
+	"testing"
+)
+
+func TestReservePrefix_NumbersRepeatedVerifications(t *testing.T) {
+	prefix := t.Name() + "/prefix"
+
+	if number := reservePrefix(t, prefix); number != 1 {
+		t.Fatalf("Should be the first verification, got %d", number)
+	}
+	if number := reservePrefix(t, prefix); number != 2 {
+		t.Fatalf("Should be the second verification, got %d", number)
+	}
+	if name := getNumberedFileName("file.Test", 2); name != "file.Test_2" {
+		t.Fatalf("Should append the verification number, got %s", name)
+	}
+	if name := getNumberedFileName("file.Test", 1); name != "file.Test" {
+		t.Fatalf("Should not number the first verification, got %s", name)
+	}
+}
+
+func TestReservePrefix_PanicsForOtherTests(t *testing.T) {
+	prefix := t.Name() + "/prefix"
+	reservePrefix(t, prefix)
+
+	t.Run("other", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Should panic when another test uses the prefix")
+			}
+		}()
+		reservePrefix(t, prefix)
+	})
+}
+
+func TestReservePrefix_ReleasedAfterTest(t *testing.T) {
+	prefix := t.Name() + "/prefix"
+
+	t.Run("first", func(t *testing.T) {
+		reservePrefix(t, prefix)
+	})
+
+	if number := reservePrefix(t, prefix); number != 1 {
+		t.Fatalf("Should be able to reuse the prefix once the test completed, got %d", number)
+	}
+}
@@ -0,0 +1,27 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/internal/naming"
+	"path/filepath"
+)
+
+// getReceivedDirectory returns the directory of the received files. When a received directory is configured,
+// the directory of the verified files is mirrored under it, relative to the module root.
+func getReceivedDirectory(settings *verifySettings, directory string) string {
+	if len(settings.receivedDirectory) == 0 {
+		return directory
+	}
+
+	moduleRoot := filepath.FromSlash(getModuleRoot())
+	root := settings.receivedDirectory
+	if !filepath.IsAbs(root) && len(moduleRoot) > 0 {
+		root = filepath.Join(moduleRoot, root)
+	}
+
+	absolute, err := filepath.Abs(directory)
+	if err != nil {
+		absolute = directory
+	}
+
+	return naming.MirrorPath(absolute, moduleRoot, root)
+}
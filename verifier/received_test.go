@@ -0,0 +1,62 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReceivedDirectory_MirrorsModuleTree(t *testing.T) {
+	settings := newSettings(t)
+	if directory := getReceivedDirectory(settings, "../_testdata"); directory != "../_testdata" {
+		t.Fatalf("Should use the verified directory by default, got %s", directory)
+	}
+
+	UseReceivedDirectory(".verify/received")(settings)
+	directory := getReceivedDirectory(settings, "../_testdata")
+	if directory != path.Join(getModuleRoot(), ".verify/received/_testdata") {
+		t.Fatalf("Should mirror the directory under the module root, got %s", directory)
+	}
+
+	scratch := filepath.ToSlash(t.TempDir())
+	UseReceivedDirectory(scratch)(settings)
+	if directory = getReceivedDirectory(settings, "."); directory != path.Join(scratch, "verifier") {
+		t.Fatalf("Should mirror the directory under the absolute directory, got %s", directory)
+	}
+}
+
+func TestReceivedDirectory_Verify(t *testing.T) {
+	verifiedDirectory := filepath.ToSlash(t.TempDir())
+	receivedRoot := filepath.ToSlash(t.TempDir())
+	receivedPath := ""
+
+	NewVerifier(t,
+		UseDirectory(verifiedDirectory),
+		UseReceivedDirectory(receivedRoot),
+		AutoVerify(),
+		OnFirstVerify(func(file FilePair) {
+			receivedPath = file.ReceivedPath
+		}),
+	).Verify("value")
+
+	if !strings.HasPrefix(receivedPath, receivedRoot+"/") {
+		t.Fatalf("Should write the received file to the received directory, got %s", receivedPath)
+	}
+	if !utils.File.Exists(path.Join(verifiedDirectory, "received_test.ReceivedDirectory_Verify.verified.txt")) {
+		t.Fatalf("Should accept the received file next to the verified files")
+	}
+}
+
+func TestReceivedDirectory_ErrorMessage(t *testing.T) {
+	builder := failingMessageBuilder{
+		settings:  newSettings(t),
+		directory: "../_testdata",
+		received:  "/tmp/received/_testdata",
+	}
+
+	if msg := builder.build(); !strings.Contains(msg, "Received Directory: /tmp/received/_testdata") {
+		t.Fatalf("Should show the received directory:\n%s", msg)
+	}
+}
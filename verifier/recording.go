@@ -0,0 +1,211 @@
+package verifier
+
+import (
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"strings"
+	"sync"
+)
+
+const recordingName = "recording"
+
+// Recording records named values and files from the code under test. The recorded values are appended to the
+// target of the next verification in the test, or in one of its subtests, in a section named `recording`,
+// in the order they were added. The recorded files are verified as separate files.
+//
+// Adding values when no recording was started does nothing, so the calls can be left in the code under test.
+// Parallel tests should record with AddFor and AppendFileFor, or with the TestRecording returned by Start, since
+// the values added to Recording can't be assigned to one of them.
+var Recording = &Recordings{
+	recordings: make(map[string]*TestRecording),
+}
+
+// Recordings records the values of the running tests, by test name. Use the Recording instance.
+type Recordings struct {
+	lock       sync.Mutex
+	recordings map[string]*TestRecording
+}
+
+// TestRecording records the values of a test
+type TestRecording struct {
+	lock    sync.Mutex
+	t       testingT
+	entries []map[string]interface{}
+	files   []Target
+}
+
+// Start starts recording for the test. The recording is stopped when the test completes.
+func (r *Recordings) Start(t testingT) *TestRecording {
+	started := &TestRecording{
+		t:       t,
+		entries: make([]map[string]interface{}, 0),
+		files:   make([]Target, 0),
+	}
+
+	r.lock.Lock()
+	r.recordings[t.Name()] = started
+	r.lock.Unlock()
+
+	t.Cleanup(func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if r.recordings[t.Name()] == started {
+			delete(r.recordings, t.Name())
+		}
+	})
+	return started
+}
+
+// IsRecording checks if a recording was started
+func (r *Recordings) IsRecording() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.recordings) > 0
+}
+
+// Add records the named value in the recording of the running test. The value is serialized and scrubbed with the target.
+func (r *Recordings) Add(name string, value interface{}) {
+	utils.Guard.AgainstEmpty(name)
+
+	if recording := r.getActive(); recording != nil {
+		recording.Add(name, value)
+	}
+}
+
+// AddFor records the named value in the recording of the test, or of the closest parent test that records
+func (r *Recordings) AddFor(t testingT, name string, value interface{}) {
+	utils.Guard.AgainstEmpty(name)
+
+	if recording := r.find(t); recording != nil {
+		recording.Add(name, value)
+	}
+}
+
+// AppendFile records the file content with the extension, e.g. `png`, in the recording of the running test.
+// The content is verified as a separate file, and the name is added to the recorded values.
+func (r *Recordings) AppendFile(name string, data []byte, extension string) {
+	utils.Guard.AgainstEmpty(name)
+	utils.Guard.AgainstBadExtension(extension)
+
+	if recording := r.getActive(); recording != nil {
+		recording.AppendFile(name, data, extension)
+	}
+}
+
+// AppendFileFor records the file content with the extension in the recording of the test, or of the closest
+// parent test that records
+func (r *Recordings) AppendFileFor(t testingT, name string, data []byte, extension string) {
+	utils.Guard.AgainstEmpty(name)
+	utils.Guard.AgainstBadExtension(extension)
+
+	if recording := r.find(t); recording != nil {
+		recording.AppendFile(name, data, extension)
+	}
+}
+
+// getActive returns the recording of the running test. When subtests record, the recording of the innermost
+// subtest is used. When unrelated tests record in parallel, no recording is returned, since the value can't be
+// assigned to one of them.
+func (r *Recordings) getActive() *TestRecording {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var innermost *TestRecording
+	for testName, recording := range r.recordings {
+		if innermost == nil || strings.HasPrefix(testName, innermost.t.Name()+"/") {
+			innermost = recording
+		}
+	}
+
+	for testName, recording := range r.recordings {
+		if recording != innermost && !strings.HasPrefix(innermost.t.Name(), testName+"/") {
+			return nil
+		}
+	}
+	return innermost
+}
+
+// Add records the named value. The value is serialized and scrubbed with the target.
+func (r *TestRecording) Add(name string, value interface{}) {
+	utils.Guard.AgainstEmpty(name)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, map[string]interface{}{name: value})
+}
+
+// AppendFile records the file content with the extension, e.g. `png`. The content is verified as a separate file,
+// and the name is added to the recorded values.
+func (r *TestRecording) AppendFile(name string, data []byte, extension string) {
+	utils.Guard.AgainstEmpty(name)
+	utils.Guard.AgainstBadExtension(extension)
+
+	var target *Target
+	if utils.File.IsText(extension) {
+		target = newStringTarget(extension, fixNewlines(string(data)))
+	} else {
+		target = newStreamTarget(extension, data)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.files = append(r.files, *target)
+	r.entries = append(r.entries, map[string]interface{}{
+		name: fmt.Sprintf("{%d bytes of %s}", len(data), extension),
+	})
+}
+
+// find returns the recording of the test, or of the closest parent test that records
+func (r *Recordings) find(t testingT) *TestRecording {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	testName := t.Name()
+	for {
+		if recording, found := r.recordings[testName]; found {
+			return recording
+		}
+		index := strings.LastIndex(testName, "/")
+		if index == -1 {
+			return nil
+		}
+		testName = testName[:index]
+	}
+}
+
+// getAppender returns the values recorded by the test, and clears them, so they are only appended to one verification
+func (r *Recordings) getAppender(t testingT) *toAppend {
+	recording := r.find(t)
+	if recording == nil {
+		return nil
+	}
+
+	recording.lock.Lock()
+	defer recording.lock.Unlock()
+
+	if len(recording.entries) == 0 {
+		return nil
+	}
+
+	entries := recording.entries
+	recording.entries = make([]map[string]interface{}, 0)
+	return &toAppend{
+		Name: recordingName,
+		Data: entries,
+	}
+}
+
+// getFiles returns the files recorded by the test, and clears them, so they are only verified once
+func (r *Recordings) getFiles(t testingT) []Target {
+	recording := r.find(t)
+	if recording == nil {
+		return emptyTargets
+	}
+
+	recording.lock.Lock()
+	defer recording.lock.Unlock()
+
+	files := recording.files
+	recording.files = make([]Target, 0)
+	return files
+}
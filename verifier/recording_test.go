@@ -0,0 +1,117 @@
+package verifier
+
+import (
+	"testing"
+)
+
+func TestRecording_StoppedWhenTestCompletes(t *testing.T) {
+	t.Run("recording", func(t *testing.T) {
+		Recording.Start(t)
+		Recording.Add("value", 1)
+
+		if appender := Recording.getAppender(t); appender == nil || appender.Name != recordingName {
+			t.Fatalf("Should return the recorded values")
+		}
+	})
+
+	if Recording.IsRecording() {
+		t.Fatalf("Should stop recording when the test completes")
+	}
+	if Recording.getAppender(t) != nil {
+		t.Fatalf("Should not append the values of another test")
+	}
+}
+
+func TestRecording_ParallelTests(t *testing.T) {
+	for _, name := range []string{"first", "second"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			recording := Recording.Start(t)
+			recording.Add("test", name)
+
+			appender := Recording.getAppender(t)
+			if appender == nil {
+				t.Fatalf("Should return the recorded values")
+			}
+			entries := appender.Data.([]map[string]interface{})
+			if len(entries) != 1 || entries[0]["test"] != name {
+				t.Fatalf("Should only return the values of the test: %v", entries)
+			}
+		})
+	}
+}
+
+func TestRecording_UnrelatedTests(t *testing.T) {
+	first := namedT{T: t, name: "TestFirst"}
+	second := namedT{T: t, name: "TestSecond"}
+	Recording.Start(first)
+	Recording.Start(second)
+
+	Recording.Add("value", 1)
+	Recording.AddFor(second, "value", 2)
+	Recording.AppendFileFor(second, "report", []byte("a"), "csv")
+
+	if Recording.getAppender(first) != nil {
+		t.Fatalf("Should not assign the value to one of the tests")
+	}
+	appender := Recording.getAppender(second)
+	if appender == nil || len(appender.Data.([]map[string]interface{})) != 2 || len(Recording.getFiles(second)) != 1 {
+		t.Fatalf("Should record the values of the test passed in")
+	}
+}
+
+func TestRecording_Subtests(t *testing.T) {
+	Recording.Start(t)
+
+	t.Run("subtest", func(t *testing.T) {
+		Recording.Add("value", 1)
+
+		if Recording.getAppender(t) == nil {
+			t.Fatalf("Should append the values of the parent test to the subtest")
+		}
+	})
+
+	t.Run("recording subtest", func(t *testing.T) {
+		Recording.Start(t)
+		Recording.Add("value", 2)
+
+		if appender := Recording.getAppender(t); appender == nil || len(appender.Data.([]map[string]interface{})) != 1 {
+			t.Fatalf("Should add the values to the innermost recording")
+		}
+	})
+}
+
+func TestRecording_ClearedWhenAppended(t *testing.T) {
+	Recording.Start(t)
+	Recording.Add("value", 1)
+	Recording.AppendFile("report", []byte("a"), "csv")
+
+	if Recording.getAppender(t) == nil || len(Recording.getFiles(t)) != 1 {
+		t.Fatalf("Should return the recorded values")
+	}
+	if Recording.getAppender(t) != nil || len(Recording.getFiles(t)) != 0 {
+		t.Fatalf("Should only append the recorded values once")
+	}
+}
+
+func TestRecording_AppendFile(t *testing.T) {
+	Recording.Start(t)
+	Recording.AppendFile("image", []byte{0x89, 0x50}, "png")
+	Recording.AppendFile("report", []byte("a\r\nb"), "csv")
+
+	files := Recording.getFiles(t)
+	if len(files) != 2 || !files[0].IsStream() || files[1].GetStringData() != "a\nb" {
+		t.Fatalf("Should record the files as targets: %+v", files)
+	}
+}
+
+// namedT runs a test under another name, to record as if unrelated tests were running
+type namedT struct {
+	*testing.T
+	name string
+}
+
+func (t namedT) Name() string {
+	return t.name
+}
@@ -0,0 +1,157 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const relaxedIndentation = "  "
+
+type relaxedKind int
+
+const (
+	relaxedNull relaxedKind = iota
+	relaxedString
+	relaxedNumber
+	relaxedBool
+	relaxedObject
+	relaxedArray
+)
+
+// relaxedNode a parsed JSON value that keeps the order of the object fields
+type relaxedNode struct {
+	kind     relaxedKind
+	value    string
+	keys     []string
+	children []*relaxedNode
+}
+
+// toRelaxed converts the serialized JSON to the relaxed text format, which is easier to read in the .txt files.
+// The keys and the simple values are not quoted, and strings are written as is, including their new lines.
+// All the members are written, including the empty and zero values.
+// Targets that weren't serialized as JSON, such as fmt.Stringer values, are returned as is.
+func toRelaxed(serialized string) string {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(serialized)))
+	decoder.UseNumber()
+
+	root, err := readRelaxedNode(decoder)
+	if err != nil || decoder.More() {
+		return serialized
+	}
+
+	builder := strings.Builder{}
+	writeRelaxedNode(&builder, root, "")
+	return builder.String()
+}
+
+func readRelaxedNode(decoder *json.Decoder) (*relaxedNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '{' {
+			return readRelaxedObject(decoder)
+		}
+		if value == '[' {
+			return readRelaxedArray(decoder)
+		}
+		return nil, fmt.Errorf("unexpected delimiter %s", value)
+	case string:
+		return &relaxedNode{kind: relaxedString, value: value}, nil
+	case json.Number:
+		return &relaxedNode{kind: relaxedNumber, value: value.String()}, nil
+	case bool:
+		return &relaxedNode{kind: relaxedBool, value: strconv.FormatBool(value)}, nil
+	case nil:
+		return &relaxedNode{kind: relaxedNull, value: "null"}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected token %v", token)
+}
+
+func readRelaxedObject(decoder *json.Decoder) (*relaxedNode, error) {
+	node := &relaxedNode{kind: relaxedObject}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := readRelaxedNode(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		node.keys = append(node.keys, key.(string))
+		node.children = append(node.children, child)
+	}
+
+	_, err := decoder.Token()
+	return node, err
+}
+
+func readRelaxedArray(decoder *json.Decoder) (*relaxedNode, error) {
+	node := &relaxedNode{kind: relaxedArray}
+	for decoder.More() {
+		child, err := readRelaxedNode(decoder)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, child)
+	}
+
+	_, err := decoder.Token()
+	return node, err
+}
+
+func writeRelaxedNode(builder *strings.Builder, node *relaxedNode, indentation string) {
+	switch node.kind {
+	case relaxedObject:
+		if len(node.children) == 0 {
+			builder.WriteString("{}")
+			return
+		}
+		builder.WriteString("{\n")
+		for i, child := range node.children {
+			builder.WriteString(indentation + relaxedIndentation)
+			builder.WriteString(node.keys[i])
+			builder.WriteString(": ")
+			writeRelaxedNode(builder, child, indentation+relaxedIndentation)
+			writeRelaxedSeparator(builder, i, len(node.children))
+		}
+		builder.WriteString(indentation + "}")
+	case relaxedArray:
+		if len(node.children) == 0 {
+			builder.WriteString("[]")
+			return
+		}
+		builder.WriteString("[\n")
+		for i, child := range node.children {
+			builder.WriteString(indentation + relaxedIndentation)
+			writeRelaxedNode(builder, child, indentation+relaxedIndentation)
+			writeRelaxedSeparator(builder, i, len(node.children))
+		}
+		builder.WriteString(indentation + "]")
+	case relaxedString:
+		if len(node.value) == 0 {
+			builder.WriteString(`""`)
+			return
+		}
+		builder.WriteString(node.value)
+	default:
+		builder.WriteString(node.value)
+	}
+}
+
+func writeRelaxedSeparator(builder *strings.Builder, index, count int) {
+	if index < count-1 {
+		builder.WriteString(",")
+	}
+	builder.WriteString("\n")
+}
@@ -0,0 +1,72 @@
+package verifier
+
+import (
+	"testing"
+)
+
+func TestToRelaxed(t *testing.T) {
+	result := toRelaxed(`{"b": "multi\nline", "a": [1, "", null], "empty": "", "list": [], "map": {}, "yes": true, "float": 0.5}`)
+
+	expected := `{
+  b: multi
+line,
+  a: [
+    1,
+    "",
+    null
+  ],
+  empty: "",
+  list: [],
+  map: {},
+  yes: true,
+  float: 0.5
+}`
+	if result != expected {
+		t.Fatalf("Should write the relaxed format:\n%s", result)
+	}
+}
+
+func TestToRelaxed_KeepsZeroValues(t *testing.T) {
+	result := toRelaxed(`{"zero": 0, "no": false, "none": null, "nested": {"inner": ""}}`)
+
+	expected := `{
+  zero: 0,
+  no: false,
+  none: null,
+  nested: {
+    inner: ""
+  }
+}`
+	if result != expected {
+		t.Fatalf("Should leave the empty values to the serializer:\n%s", result)
+	}
+}
+
+func TestToRelaxed_Values(t *testing.T) {
+	cases := map[string]string{
+		`"text"`:         "text",
+		`{}`:             "{}",
+		`[]`:             "[]",
+		`[{}]`:           "[\n  {}\n]",
+		`12`:             "12",
+		`Stringer value`: "Stringer value",
+	}
+
+	for input, expected := range cases {
+		if result := toRelaxed(input); result != expected {
+			t.Fatalf("Should convert %s to %s, got %s", input, expected, result)
+		}
+	}
+}
+
+func TestAsJSON_RelaxedByDefault(t *testing.T) {
+	s := newSettings(t)
+	if result := asJSON(Address{Street: "Main"}, nil, s).String(); result != "{\n  Street: Main\n}" {
+		t.Fatalf("Should use the relaxed format for txt files:\n%s", result)
+	}
+
+	UseStrictJSON()(s)
+	if result := asJSON(Address{Street: "Main"}, nil, s).String(); result != "{\n    \"Street\": \"Main\",\n    \"Country\": \"\",\n    \"Suburb\": \"\"\n}" {
+		t.Fatalf("Should use JSON for json files:\n%s", result)
+	}
+}
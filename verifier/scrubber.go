@@ -11,6 +11,9 @@ import (
 	"time"
 )
 
+// scrubbedValue replaces the values of scrubbed members and headers
+const scrubbedValue = "{Scrubbed}"
+
 type dirReplacement struct {
 	Directory string
 	Mask      string
@@ -61,6 +64,11 @@ func (s *dataScrubber) Apply(extension string, target *strings.Builder, settings
 	stringData := target.String()
 	target.Reset()
 
+	// stack traces are scrubbed before the directory replacements to get module relative paths
+	if settings.scrubStackTraces {
+		stringData = s.ScrubStackTrace(stringData, !settings.keepStackTraceParams)
+	}
+
 	for _, replacement := range directoryReplacements {
 		stringData = strings.ReplaceAll(stringData, replacement.Directory, replacement.Mask)
 	}
@@ -109,15 +117,15 @@ func (s *dataScrubber) ScrubMachineName(target string) string {
 	return target
 }
 
-// ScrubStackTrace scrubs the stacktrace
+// ScrubStackTrace scrubs the goroutine traces printed by panics and `runtime/debug.Stack()`.
+// Goroutine numbers, argument values and PC offsets are normalised and file paths are made relative
+// to the module root. When removeParams is set, the argument lists are replaced with `(...)`.
 func (s *dataScrubber) ScrubStackTrace(stacktrace string, removeParams bool) string {
 	if len(stacktrace) == 0 {
 		return stacktrace
 	}
 
-	//TODO: parse stacktrace
-
-	return stacktrace
+	return newStackTraceScrubber(removeParams).scrub(stacktrace)
 }
 
 func (s *dataScrubber) removeLinesContaining(input string, ignoreCase bool, stringToMatch ...string) string {
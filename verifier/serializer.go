@@ -47,6 +47,11 @@ func (s *serializer) registerExtensions() {
 	s.json.RegisterTypeEncoder(uuidType.String(), newUUIDEncoder(s))
 	s.json.RegisterTypeEncoder(timeType.String(), newTimeEncoder(s))
 	s.json.RegisterTypeEncoder(textMarshalerType.String(), newTextMarshallerEncoder(s))
+	s.registerConverters()
+	s.json.RegisterExtension(newMemberExtension(s.settings))
+	if s.settings.shouldIgnoreEmptyValues() {
+		s.json.RegisterExtension(&emptyValueExtension{})
+	}
 }
 
 func createMarshaller() jsoner.API {
@@ -201,6 +206,11 @@ func asJSON(input interface{}, appenders []toAppend, settings *verifySettings) *
 
 	serializer := newSerializer(settings, settings.scrubber)
 	serialized := serializer.Serialize(input)
+	if settings.yaml {
+		serialized = toYAML(serialized)
+	} else if !settings.strictJSON {
+		serialized = toRelaxed(serialized)
+	}
 
 	builder := strings.Builder{}
 	builder.WriteString(serialized)
@@ -232,6 +232,7 @@ func TestSerializeStringerInterface(t *testing.T) {
 
 func getTestSerializer(t *testing.T, scrubGuid, scrubTime bool) *serializer {
 	settings := newSettings(t)
+	IncludeDefaults()(settings)
 	if !scrubGuid {
 		DontScrubGuids()(settings)
 	}
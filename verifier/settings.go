@@ -3,25 +3,46 @@ package verifier
 import (
 	"github.com/VerifyTests/Verify.Go/diff"
 	"github.com/VerifyTests/Verify.Go/utils"
+	"net/http"
+	"reflect"
 	"strings"
 )
 
 type verifySettings struct {
 	directory                        string
+	receivedDirectory                string
 	autoVerify                       bool
+	autoVerifyOnCI                   bool
 	diffDisabled                     bool
 	strictJSON                       bool
+	yaml                             bool
+	ignoreEmptyValues                bool
+	includeDefaults                  bool
 	scrubGuids                       bool
 	scrubTimes                       bool
+	scrubStackTraces                 bool
+	keepStackTraceParams             bool
 	omitContentFromError             bool
 	uniqueForArchitecture            bool
 	uniqueForOperatingSystem         bool
 	uniqueForRuntime                 bool
+	disableRequireUniquePrefix       bool
 	instanceScrubbers                []InstanceScrubber
 	fileAppender                     []FileAppenderFunc
 	jsonAppender                     []JSONAppenderFunc
 	extensionMappedInstanceScrubbers map[string][]InstanceScrubber
+	scrubbedHTTPHeaders              map[string]bool
+	ignoredMembers                   map[reflect.Type]map[string]bool
+	ignoredMemberTypes               map[reflect.Type]bool
+	scrubbedMembers                  map[string]bool
+	converters                       map[reflect.Type]TypeConverterFunc
+	fileConverters                   map[string]FileConverterFunc
+	textDiff                         TextDiffOptions
 	testCase                         string
+	fileName                         string
+	typeName                         string
+	methodName                       string
+	fileConvention                   FileConventionFunc
 	extension                        string
 	defaultExtension                 string
 	ciDetected                       diff.CIDetected
@@ -29,9 +50,8 @@ type verifySettings struct {
 	counter                          *countHolder
 	defaultStringComparer            StringComparerFunc
 	stringComparers                  map[string]StringComparerFunc
+	defaultStreamComparer            StreamComparerFunc
 	streamComparers                  map[string]StreamComparerFunc
-	streamComparer                   StreamComparerFunc
-	stringComparer                   StringComparerFunc
 	onBeforeVerify                   BeforeVerifyFunc
 	onAfterVerify                    AfterVerifyFunc
 	onFirstVerify                    FirstVerifyFunc
@@ -105,6 +125,13 @@ func UniqueForRuntime() VerifyConfigure {
 	}
 }
 
+// DisableRequireUniquePrefix allows multiple verifications to share the same file prefix
+func DisableRequireUniquePrefix() VerifyConfigure {
+	return func(s *verifySettings) {
+		s.disableRequireUniquePrefix = true
+	}
+}
+
 // OmitContentFromError show the content differences when a mismatch occurs during verification
 func OmitContentFromError() VerifyConfigure {
 	return func(s *verifySettings) {
@@ -112,6 +139,40 @@ func OmitContentFromError() VerifyConfigure {
 	}
 }
 
+// UseTextDiff configures the unified diff of the text files shown in the failure message.
+// Only the non-zero fields of the options are used, the other fields keep their current values.
+// Use UseTextDiffContextLines and UseTextDiffMaxHunkLines to set the line counts to zero.
+func UseTextDiff(options TextDiffOptions) VerifyConfigure {
+	return func(s *verifySettings) {
+		if options.ContextLines != 0 {
+			s.textDiff.ContextLines = options.ContextLines
+		}
+		if options.WordDiff {
+			s.textDiff.WordDiff = true
+		}
+		if options.Color != DiffColorAuto {
+			s.textDiff.Color = options.Color
+		}
+		if options.MaxHunkLines != 0 {
+			s.textDiff.MaxHunkLines = options.MaxHunkLines
+		}
+	}
+}
+
+// UseTextDiffContextLines sets the number of unchanged lines shown around each change of the text diff
+func UseTextDiffContextLines(lines int) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.textDiff.ContextLines = lines
+	}
+}
+
+// UseTextDiffMaxHunkLines sets the maximum number of lines shown for each hunk of the text diff. Zero shows all the lines.
+func UseTextDiffMaxHunkLines(lines int) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.textDiff.MaxHunkLines = lines
+	}
+}
+
 // DisableDiff enables the diff tools
 func DisableDiff() VerifyConfigure {
 	return func(s *verifySettings) {
@@ -126,6 +187,14 @@ func UseStrictJSON() VerifyConfigure {
 	}
 }
 
+// UseYAML serializes the targets as YAML and uses the .yaml extension for the outputted files.
+// The values are scrubbed like the JSON output.
+func UseYAML() VerifyConfigure {
+	return func(s *verifySettings) {
+		s.yaml = true
+	}
+}
+
 // DontScrubGuids do not auto-scrub UUID values
 func DontScrubGuids() VerifyConfigure {
 	return func(s *verifySettings) {
@@ -148,17 +217,35 @@ func UseExtension(extension string) VerifyConfigure {
 	}
 }
 
-// UseStreamComparer use the specified function for stream comparison
+// UseStreamComparer use the specified function for stream comparison of all the extensions
+// that do not have a comparer registered with UseStreamComparerForExtension
 func UseStreamComparer(fun StreamComparerFunc) VerifyConfigure {
 	return func(s *verifySettings) {
-		s.streamComparer = fun
+		s.defaultStreamComparer = fun
+	}
+}
+
+// UseStreamComparerForExtension use the specified function for stream comparison of files with the extension
+func UseStreamComparerForExtension(extension string, fun StreamComparerFunc) VerifyConfigure {
+	return func(s *verifySettings) {
+		utils.Guard.AgainstBadExtension(extension)
+		s.streamComparers[extension] = fun
 	}
 }
 
-// UseStringComparer use the specified function for string comparison
+// UseStringComparer use the specified function for string comparison of all the extensions
+// that do not have a comparer registered with UseStringComparerForExtension
 func UseStringComparer(fun StringComparerFunc) VerifyConfigure {
 	return func(s *verifySettings) {
-		s.stringComparer = fun
+		s.defaultStringComparer = fun
+	}
+}
+
+// UseStringComparerForExtension use the specified function for string comparison of files with the extension
+func UseStringComparerForExtension(extension string, fun StringComparerFunc) VerifyConfigure {
+	return func(s *verifySettings) {
+		utils.Guard.AgainstBadExtension(extension)
+		s.stringComparers[extension] = fun
 	}
 }
 
@@ -169,6 +256,14 @@ func UseDirectory(directory string) VerifyConfigure {
 	}
 }
 
+// UseReceivedDirectory writes the received files to a tree under the directory that mirrors the verified files,
+// instead of next to the verified files. A relative directory is relative to the module root.
+func UseReceivedDirectory(directory string) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.receivedDirectory = directory
+	}
+}
+
 // AddScrubber add a function to the front of the scrubber collections.
 func AddScrubber(fun InstanceScrubber) VerifyConfigure {
 	return func(s *verifySettings) {
@@ -217,6 +312,35 @@ func ScrubLinesContaining(stringToMatch ...string) VerifyConfigure {
 	}
 }
 
+// ScrubStackTraces scrubs the goroutine traces printed by panics and `runtime/debug.Stack()`.
+// Goroutine numbers and PC offsets are removed, and file paths are made relative to the module root.
+// When removeParams is set, the argument lists are replaced with `(...)`.
+func ScrubStackTraces(removeParams bool) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.scrubStackTraces = true
+		s.keepStackTraceParams = !removeParams
+	}
+}
+
+// ScrubHTTPHeaders scrubs the values of the headers when verifying HTTP responses, in addition to the
+// volatile headers that are scrubbed by default
+func ScrubHTTPHeaders(headers ...string) VerifyConfigure {
+	return func(s *verifySettings) {
+		for _, header := range headers {
+			s.scrubbedHTTPHeaders[http.CanonicalHeaderKey(header)] = true
+		}
+	}
+}
+
+// DontScrubHTTPHeaders keeps the values of the headers when verifying HTTP responses, e.g. `Content-Length`
+func DontScrubHTTPHeaders(headers ...string) VerifyConfigure {
+	return func(s *verifySettings) {
+		for _, header := range headers {
+			delete(s.scrubbedHTTPHeaders, http.CanonicalHeaderKey(header))
+		}
+	}
+}
+
 // ScrubInlineGuids scrubs inline UUID values with string types
 func ScrubInlineGuids() VerifyConfigure {
 	return func(s *verifySettings) {
@@ -275,6 +399,34 @@ func TestCase(name string) VerifyConfigure {
 	}
 }
 
+// UseFileConvention use a custom convention to name the verified files and their directory
+func UseFileConvention(fun FileConventionFunc) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.fileConvention = fun
+	}
+}
+
+// UseFileName use the name as the file name prefix, instead of the name based on the source file and test name
+func UseFileName(fileName string) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.fileName = fileName
+	}
+}
+
+// UseTypeName use the name instead of the test source file name
+func UseTypeName(name string) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.typeName = name
+	}
+}
+
+// UseMethodName use the name instead of the test name
+func UseMethodName(name string) VerifyConfigure {
+	return func(s *verifySettings) {
+		s.methodName = name
+	}
+}
+
 func (s *verifySettings) tryGetStringComparer(extension string) (StringComparerFunc, bool) {
 	comp, ok := s.stringComparers[extension]
 	if ok {
@@ -285,7 +437,20 @@ func (s *verifySettings) tryGetStringComparer(extension string) (StringComparerF
 		return s.defaultStringComparer, true
 	}
 
-	return nil, false
+	return tryGetGlobalStringComparer(extension)
+}
+
+func (s *verifySettings) tryGetStreamComparer(extension string) (StreamComparerFunc, bool) {
+	comp, ok := s.streamComparers[extension]
+	if ok {
+		return comp, true
+	}
+
+	if s.defaultStreamComparer != nil {
+		return s.defaultStreamComparer, true
+	}
+
+	return tryGetGlobalStreamComparer(extension)
 }
 
 func (s *verifySettings) extensionOrTxt() string {
@@ -333,6 +498,9 @@ func (s *verifySettings) getJSONAppenders() []toAppend {
 			result = append(result, *data)
 		}
 	}
+	if recorded := Recording.getAppender(s.t); recorded != nil {
+		result = append(result, *recorded)
+	}
 	return result
 }
 
@@ -344,7 +512,7 @@ func (s *verifySettings) getFileAppenders() []Target {
 			result = append(result, *stream)
 		}
 	}
-	return result
+	return append(result, Recording.getFiles(s.t)...)
 }
 
 func newSettings(t testingT) *verifySettings {
@@ -352,15 +520,33 @@ func newSettings(t testingT) *verifySettings {
 		scrubGuids:                       true,
 		scrubTimes:                       true,
 		extensionMappedInstanceScrubbers: make(map[string][]InstanceScrubber),
+		scrubbedHTTPHeaders:              newScrubbedHTTPHeaders(),
+		ignoredMembers:                   make(map[reflect.Type]map[string]bool),
+		ignoredMemberTypes:               make(map[reflect.Type]bool),
+		scrubbedMembers:                  make(map[string]bool),
+		converters:                       make(map[reflect.Type]TypeConverterFunc),
+		fileConverters:                   make(map[string]FileConverterFunc),
 		instanceScrubbers:                make([]InstanceScrubber, 0),
 		fileAppender:                     make([]FileAppenderFunc, 0),
 		jsonAppender:                     make([]JSONAppenderFunc, 0),
 		streamComparers:                  make(map[string]StreamComparerFunc),
 		stringComparers:                  make(map[string]StringComparerFunc),
 		scrubber:                         newDataScrubber(startCounter()),
+		textDiff:                         defaultTextDiffOptions(),
 		ciDetected:                       diff.CheckCI(),
 		diffDisabled:                     diff.CheckDisabled(),
 		autoVerify:                       false,
 		t:                                t,
 	}
 }
+
+// serializedExtension returns the extension of the files of the serialized targets
+func (s *verifySettings) serializedExtension() string {
+	if s.yaml {
+		return yamlExtension
+	}
+	if s.strictJSON {
+		return jsonExtension
+	}
+	return textExtension
+}
@@ -49,3 +49,22 @@ func TestVerifySettings_AddScrubberForExtension(t *testing.T) {
 		t.Fatalf("extension scrubber for 'json' should have 1 instance")
 	}
 }
+
+func TestVerifySettings_UseTextDiffKeepsDefaults(t *testing.T) {
+	s := newSettings(t)
+	UseTextDiff(TextDiffOptions{WordDiff: true})(s)
+
+	defaults := defaultTextDiffOptions()
+	if !s.textDiff.WordDiff {
+		t.Fatalf("Should use the non-zero fields")
+	}
+	if s.textDiff.ContextLines != defaults.ContextLines || s.textDiff.MaxHunkLines != defaults.MaxHunkLines {
+		t.Fatalf("Should keep the defaults of the zero fields, got %+v", s.textDiff)
+	}
+
+	UseTextDiffContextLines(0)(s)
+	UseTextDiffMaxHunkLines(0)(s)
+	if s.textDiff.ContextLines != 0 || s.textDiff.MaxHunkLines != 0 {
+		t.Fatalf("Should set the line counts to zero, got %+v", s.textDiff)
+	}
+}
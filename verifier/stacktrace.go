@@ -0,0 +1,166 @@
+package verifier
+
+import (
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	goroutineHeaderPattern = regexp.MustCompile(`^goroutine (\d+)(?: gp=0x[0-9a-f]+ m=\S+(?: mp=0x[0-9a-f]+)?)? \[(.*)\]:$`)
+	createdByPattern       = regexp.MustCompile(`^(created by .+) in goroutine (\d+)$`)
+	frameFilePattern       = regexp.MustCompile(`^\t(.+):(\d+)(?: \+0x[0-9a-f]+)?$`)
+	waitDurationPattern    = regexp.MustCompile(`, \d+ minutes?`)
+	argumentValuePattern   = regexp.MustCompile(`0x[0-9a-f]+\??`)
+	signalAddressPattern   = regexp.MustCompile(`\b(addr|pc)=0x[0-9a-f]+`)
+)
+
+var moduleRootOnce = &sync.Once{}
+var moduleRoot string
+
+// getModuleRoot returns the directory of the go.mod file of the module being tested
+func getModuleRoot() string {
+	moduleRootOnce.Do(func() {
+		if goMod, found := utils.File.FindInParents(utils.File.GetCurrentDirectory(), "go.mod"); found {
+			moduleRoot = filepath.ToSlash(filepath.Dir(goMod))
+		}
+	})
+	return moduleRoot
+}
+
+func getGoRoot() string {
+	if root, found := os.LookupEnv("GOROOT"); found {
+		return filepath.ToSlash(root)
+	}
+	// the GOROOT the binary was built with is the one that appears in the stack traces
+	return filepath.ToSlash(runtime.GOROOT())
+}
+
+type stackTraceScrubber struct {
+	removeParams bool
+	goroutines   map[string]int
+	moduleRoot   string
+	goRoot       string
+}
+
+func newStackTraceScrubber(removeParams bool) *stackTraceScrubber {
+	return &stackTraceScrubber{
+		removeParams: removeParams,
+		goroutines:   make(map[string]int),
+		moduleRoot:   getModuleRoot(),
+		goRoot:       getGoRoot(),
+	}
+}
+
+// scrub normalises the goroutine traces found in the input, as printed by panics and `runtime/debug.Stack()`.
+// The rest of the input is left untouched.
+func (s *stackTraceScrubber) scrub(input string) string {
+	lines := strings.Split(input, "\n")
+	inTrace := false
+
+	for i, line := range lines {
+		if match := goroutineHeaderPattern.FindStringSubmatch(line); match != nil {
+			inTrace = true
+			status := waitDurationPattern.ReplaceAllString(match[2], "")
+			lines[i] = fmt.Sprintf("goroutine %d [%s]:", s.goroutineNumber(match[1]), status)
+			continue
+		}
+
+		if !inTrace {
+			lines[i] = signalAddressPattern.ReplaceAllString(line, "$1=?")
+			continue
+		}
+
+		if len(strings.TrimSpace(line)) == 0 {
+			inTrace = false
+			continue
+		}
+
+		if match := frameFilePattern.FindStringSubmatch(line); match != nil {
+			lines[i] = fmt.Sprintf("\t%s:%s", s.normalizePath(match[1]), match[2])
+			continue
+		}
+
+		if match := createdByPattern.FindStringSubmatch(line); match != nil {
+			lines[i] = fmt.Sprintf("%s in goroutine %d", match[1], s.goroutineNumber(match[2]))
+			continue
+		}
+
+		lines[i] = s.normalizeFunction(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *stackTraceScrubber) goroutineNumber(id string) int {
+	if number, found := s.goroutines[id]; found {
+		return number
+	}
+
+	number := len(s.goroutines) + 1
+	s.goroutines[id] = number
+	return number
+}
+
+func (s *stackTraceScrubber) normalizeFunction(line string) string {
+	if !strings.HasSuffix(line, ")") {
+		return line
+	}
+
+	start := findArgumentsStart(line)
+	if start == -1 {
+		return line
+	}
+
+	name := line[:start]
+	arguments := line[start+1 : len(line)-1]
+	if s.removeParams || arguments == "..." {
+		if len(arguments) == 0 {
+			return name + "()"
+		}
+		return name + "(...)"
+	}
+
+	return fmt.Sprintf("%s(%s)", name, argumentValuePattern.ReplaceAllString(arguments, "?"))
+}
+
+// findArgumentsStart finds the opening parenthesis of the argument list at the end of a function line.
+// Receivers such as `(*T)` are part of the function name.
+func findArgumentsStart(line string) int {
+	depth := 0
+	for i := len(line) - 1; i >= 0; i-- {
+		switch line[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (s *stackTraceScrubber) normalizePath(file string) string {
+	file = filepath.ToSlash(file)
+
+	if len(s.moduleRoot) > 0 && strings.HasPrefix(file, s.moduleRoot+"/") {
+		return file[len(s.moduleRoot)+1:]
+	}
+
+	if len(s.goRoot) > 0 && strings.HasPrefix(file, s.goRoot+"/") {
+		return "{GoRoot}/" + file[len(s.goRoot)+1:]
+	}
+
+	if index := strings.Index(file, "/pkg/mod/"); index != -1 {
+		return "{GoModCache}/" + file[index+len("/pkg/mod/"):]
+	}
+
+	return file
+}
@@ -0,0 +1,129 @@
+package verifier
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func sampleStackTrace() string {
+	root := getModuleRoot()
+	goRoot := getGoRoot()
+	return fmt.Sprintf(`panic: something went wrong [recovered]
+
+goroutine 7 [running]:
+runtime/debug.Stack()
+	%[2]s/src/runtime/debug/stack.go:26 +0x5e
+example.com/app.(*Service).Handle({0x4edca0?, 0x648f18?}, 0x6d51c0?, {0x345c41886f60?, 0x0?, 0x0?})
+	%[1]s/app/service.go:42 +0x13
+example.com/app.inlined(...)
+	%[1]s/app/service.go:12
+github.com/google/uuid.New()
+	/home/user/go/pkg/mod/github.com/google/uuid@v1.3.0/version4.go:14 +0x1a3
+created by testing.(*T).Run in goroutine 1
+	%[2]s/src/testing/testing.go:2258 +0x4d4
+
+goroutine 31 [chan receive, 5 minutes]:
+example.com/app.worker()
+	%[1]s/app/worker.go:8 +0x25
+created by example.com/app.(*Service).Handle in goroutine 7
+	%[1]s/app/service.go:40 +0x91`, root, goRoot)
+}
+
+func TestScrubStackTrace_RemoveParams(t *testing.T) {
+	scrubber := newDataScrubber(startCounter())
+	scrubbed := scrubber.ScrubStackTrace(sampleStackTrace(), true)
+
+	expected := `panic: something went wrong [recovered]
+
+goroutine 1 [running]:
+runtime/debug.Stack()
+	{GoRoot}/src/runtime/debug/stack.go:26
+example.com/app.(*Service).Handle(...)
+	app/service.go:42
+example.com/app.inlined(...)
+	app/service.go:12
+github.com/google/uuid.New()
+	{GoModCache}/github.com/google/uuid@v1.3.0/version4.go:14
+created by testing.(*T).Run in goroutine 2
+	{GoRoot}/src/testing/testing.go:2258
+
+goroutine 3 [chan receive]:
+example.com/app.worker()
+	app/worker.go:8
+created by example.com/app.(*Service).Handle in goroutine 1
+	app/service.go:40`
+
+	if scrubbed != expected {
+		t.Fatalf("unexpected scrubbed stack trace:\n%s", scrubbed)
+	}
+}
+
+func TestScrubStackTrace_KeepParams(t *testing.T) {
+	scrubber := newDataScrubber(startCounter())
+	scrubbed := scrubber.ScrubStackTrace(sampleStackTrace(), false)
+
+	if !strings.Contains(scrubbed, "example.com/app.(*Service).Handle({?, ?}, ?, {?, ?, ?})\n") {
+		t.Fatalf("argument values should have been normalised:\n%s", scrubbed)
+	}
+	if !strings.Contains(scrubbed, "example.com/app.inlined(...)\n") {
+		t.Fatalf("elided arguments should be kept:\n%s", scrubbed)
+	}
+}
+
+func TestScrubStackTrace_LeavesOtherTextUntouched(t *testing.T) {
+	input := "Result: Method(0x1f)\n\tnot/a/frame.txt"
+
+	scrubber := newDataScrubber(startCounter())
+	if scrubbed := scrubber.ScrubStackTrace(input, true); scrubbed != input {
+		t.Fatalf("text outside of a goroutine trace should not change:\n%s", scrubbed)
+	}
+}
+
+func TestScrubStackTrace_RuntimeStack(t *testing.T) {
+	scrubber := newDataScrubber(startCounter())
+	scrubbed := scrubber.ScrubStackTrace(string(debug.Stack()), true)
+
+	if !strings.HasPrefix(scrubbed, "goroutine 1 [running]:") {
+		t.Fatalf("goroutine number should be normalised:\n%s", scrubbed)
+	}
+	if strings.Contains(scrubbed, "+0x") {
+		t.Fatalf("PC offsets should be removed:\n%s", scrubbed)
+	}
+	if !strings.Contains(scrubbed, "\tverifier/stacktrace_test.go:") {
+		t.Fatalf("paths should be relative to the module:\n%s", scrubbed)
+	}
+}
+
+func TestScrubStackTraces_Setting(t *testing.T) {
+	s := newSettings(t)
+	ScrubStackTraces(true)(s)
+
+	builder := strings.Builder{}
+	builder.WriteString(string(debug.Stack()))
+	s.scrubber.Apply(textExtension, &builder, s)
+
+	if strings.Contains(builder.String(), "+0x") {
+		t.Fatalf("stack trace should have been scrubbed:\n%s", builder.String())
+	}
+	if strings.Contains(builder.String(), "{CurrentDirectory}") {
+		t.Fatalf("paths should be relative to the module instead of the current directory:\n%s", builder.String())
+	}
+}
+
+func TestScrubStackTraces_SettingKeepsParams(t *testing.T) {
+	s := newSettings(t)
+	ScrubStackTraces(false)(s)
+
+	builder := strings.Builder{}
+	builder.WriteString(sampleStackTrace())
+	s.scrubber.Apply(textExtension, &builder, s)
+
+	if !strings.Contains(builder.String(), "example.com/app.(*Service).Handle({?, ?}, ?, {?, ?, ?})\n") {
+		t.Fatalf("argument lists should have been kept:\n%s", builder.String())
+	}
+	if strings.Contains(builder.String(), "+0x") {
+		t.Fatalf("stack trace should have been scrubbed:\n%s", builder.String())
+	}
+}
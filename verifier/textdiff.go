@@ -0,0 +1,58 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/internal/textdiff"
+)
+
+// DiffColor controls the use of ANSI colors in the text diff
+type DiffColor int
+
+const (
+	//DiffColorAuto uses colors when the standard output is a terminal
+	DiffColorAuto DiffColor = iota
+	//DiffColorAlways always uses colors
+	DiffColorAlways
+	//DiffColorNever never uses colors
+	DiffColorNever
+)
+
+// TextDiffOptions options for the unified diff of the text files in the failure message
+type TextDiffOptions struct {
+	// ContextLines the number of unchanged lines shown around each change
+	ContextLines int
+	// WordDiff highlights the changed words within the changed lines
+	WordDiff bool
+	// Color controls the use of ANSI colors
+	Color DiffColor
+	// MaxHunkLines the maximum number of lines shown for each hunk. Zero shows all the lines.
+	MaxHunkLines int
+}
+
+func defaultTextDiffOptions() TextDiffOptions {
+	return TextDiffOptions{
+		ContextLines: textdiff.DefaultContextLines,
+		WordDiff:     false,
+		Color:        DiffColorAuto,
+		MaxHunkLines: textdiff.DefaultMaxHunkLines,
+	}
+}
+
+// unifiedDiff creates a unified diff of the verified and received texts
+func unifiedDiff(options TextDiffOptions, verifiedName, receivedName, verified, received string) string {
+	return textdiff.Unified(verifiedName, receivedName, verified, received, textdiff.Options{
+		ContextLines: options.ContextLines,
+		WordDiff:     options.WordDiff,
+		UseColor:     shouldUseColor(options.Color),
+		MaxHunkLines: options.MaxHunkLines,
+	})
+}
+
+func shouldUseColor(color DiffColor) bool {
+	switch color {
+	case DiffColorAlways:
+		return true
+	case DiffColorNever:
+		return false
+	}
+	return textdiff.IsColorTerminal()
+}
@@ -0,0 +1,20 @@
+package verifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff_Color(t *testing.T) {
+	options := defaultTextDiffOptions()
+
+	options.Color = DiffColorAlways
+	if result := unifiedDiff(options, "verified", "received", "old", "new"); !strings.Contains(result, "\x1b[") {
+		t.Fatalf("Should use colors:\n%q", result)
+	}
+
+	options.Color = DiffColorNever
+	if result := unifiedDiff(options, "verified", "received", "old", "new"); strings.Contains(result, "\x1b[") {
+		t.Fatalf("Should not use colors:\n%q", result)
+	}
+}
@@ -0,0 +1,155 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/utils"
+	"github.com/google/uuid"
+	"github.com/heskandari/jsoner"
+	"github.com/modern-go/reflect2"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// TypeConverterFunc writes the value of the converted type to the serialized target
+type TypeConverterFunc func(value interface{}, writer *Writer)
+
+// Writer writes the converted values to the serialized target. Strings, UUID and time.Time values are
+// scrubbed like the other values of the target.
+type Writer struct {
+	stream     *jsoner.Stream
+	serializer *serializer
+}
+
+// converterExtension creates the encoders of the converted types
+type converterExtension struct {
+	jsoner.DummyExtension
+	serializer *serializer
+}
+
+type typeConverterEncoder struct {
+	fun        TypeConverterFunc
+	valueType  reflect2.Type
+	serializer *serializer
+}
+
+// RegisterConverter registers the converter of the type for all the verifications. The type is passed as a value,
+// e.g. `RegisterConverter(Money{}, writeMoney)`. The converters added with AddConverter take precedence.
+func RegisterConverter(target interface{}, fun TypeConverterFunc) {
+	valueType := getConvertedType(target)
+	updateDefaults(func(d *defaults) {
+		d.converters[valueType] = fun
+	})
+}
+
+// AddConverter uses the converter to write the values of the type, and the pointers to them, in the serialized
+// targets. The type is passed as a value, e.g. `AddConverter(Money{}, writeMoney)`.
+func AddConverter(target interface{}, fun TypeConverterFunc) VerifyConfigure {
+	valueType := getConvertedType(target)
+	return func(s *verifySettings) {
+		s.converters[valueType] = fun
+	}
+}
+
+func getConvertedType(target interface{}) reflect.Type {
+	utils.Guard.AgainstNil(target)
+
+	valueType := reflect.TypeOf(target)
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	return valueType
+}
+
+// registerConverters registers the extension that writes the converted types with their converters
+func (s *serializer) registerConverters() {
+	s.json.RegisterExtension(&converterExtension{serializer: s})
+}
+
+// CreateEncoder creates the encoder of the types with a converter. The pointers to the types are written
+// by jsoner with the encoder of the type they point to.
+func (e *converterExtension) CreateEncoder(typ reflect2.Type) jsoner.ValEncoder {
+	fun := e.serializer.settings.getConverter(typ.Type1())
+	if fun == nil {
+		return nil
+	}
+	return &typeConverterEncoder{
+		fun:        fun,
+		valueType:  typ,
+		serializer: e.serializer,
+	}
+}
+
+// getConverter returns the converter of the type, from the settings or from the global converters
+func (s *verifySettings) getConverter(typ reflect.Type) (fun TypeConverterFunc) {
+	if fun, found := s.converters[typ]; found {
+		return fun
+	}
+
+	readDefaults(func(d *defaults) {
+		fun = d.converters[typ]
+	})
+	return
+}
+
+// hasConverter checks if a converter was registered for the type, or the type it points to,
+// either globally or in the settings
+func (s *verifySettings) hasConverter(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return s.getConverter(typ) != nil
+}
+
+func (e *typeConverterEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return ptr == nil || reflect.ValueOf(e.valueType.UnsafeIndirect(ptr)).IsZero()
+}
+
+func (e *typeConverterEncoder) Encode(ptr unsafe.Pointer, stream *jsoner.Stream) {
+	e.fun(e.valueType.UnsafeIndirect(ptr), &Writer{
+		stream:     stream,
+		serializer: e.serializer,
+	})
+}
+
+// WriteString writes a string. UUID and time values in RFC3339 format are scrubbed.
+func (w *Writer) WriteString(value string) {
+	w.stream.WriteString(w.serializer.convertString(value))
+}
+
+// WriteInt writes an integer
+func (w *Writer) WriteInt(value int64) {
+	w.stream.WriteInt64(value)
+}
+
+// WriteFloat writes a floating point number
+func (w *Writer) WriteFloat(value float64) {
+	w.stream.WriteFloat64(value)
+}
+
+// WriteBool writes a boolean
+func (w *Writer) WriteBool(value bool) {
+	w.stream.WriteBool(value)
+}
+
+// WriteNull writes null
+func (w *Writer) WriteNull() {
+	w.stream.WriteNil()
+}
+
+// WriteGUID writes the scrubbed UUID, unless scrubbing UUID values is disabled
+func (w *Writer) WriteGUID(value uuid.UUID) {
+	w.stream.WriteString(w.serializer.convertUUID(value))
+}
+
+// WriteTime writes the scrubbed time, unless scrubbing times is disabled
+func (w *Writer) WriteTime(value time.Time) {
+	w.stream.WriteString(w.serializer.convertTime(value))
+}
+
+// WriteValue serializes the value like the rest of the target, e.g. a struct or a map with the converted fields
+func (w *Writer) WriteValue(value interface{}) {
+	w.stream.WriteVal(value)
+}
@@ -0,0 +1,111 @@
+package verifier
+
+import (
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"github.com/google/uuid"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type cents int64
+
+type priced struct {
+	Name  string
+	Price cents
+	Sale  *cents
+	Ref   uuid.UUID
+}
+
+func writeCents(value interface{}, writer *Writer) {
+	amount := value.(cents)
+	writer.WriteString(fmt.Sprintf("$%d.%02d", amount/100, amount%100))
+}
+
+func TestAddConverter(t *testing.T) {
+	sale := cents(999)
+	serialized := serializeWithRules(t, priced{Name: "book", Price: 1250, Sale: &sale}, AddConverter(cents(0), writeCents))
+
+	if !strings.Contains(serialized, `"Price": "$12.50"`) {
+		t.Fatalf("Should convert the values:\n%s", serialized)
+	}
+	if !strings.Contains(serialized, `"Sale": "$9.99"`) {
+		t.Fatalf("Should convert the pointers to the values:\n%s", serialized)
+	}
+}
+
+func TestAddConverter_Scrubs(t *testing.T) {
+	id := uuid.New()
+	serialized := serializeWithRules(t, priced{Name: "book"}, AddConverter(priced{}, func(value interface{}, writer *Writer) {
+		writer.WriteString(id.String())
+	}))
+
+	if strings.Contains(serialized, id.String()) {
+		t.Fatalf("Should scrub the converted strings:\n%s", serialized)
+	}
+	if !strings.Contains(serialized, `"Guid_1"`) {
+		t.Fatalf("Should write the converted value:\n%s", serialized)
+	}
+}
+
+func TestRegisterConverter_AddConverterTakesPrecedence(t *testing.T) {
+	RegisterConverter(cents(0), func(value interface{}, writer *Writer) {
+		writer.WriteInt(int64(value.(cents)))
+	})
+	t.Cleanup(ResetDefaults)
+
+	serialized := serializeWithRules(t, priced{Price: 1250})
+	if !strings.Contains(serialized, `"Price": 1250`) {
+		t.Fatalf("Should use the global converter:\n%s", serialized)
+	}
+
+	serialized = serializeWithRules(t, priced{Price: 1250}, AddConverter(cents(0), writeCents))
+	if !strings.Contains(serialized, `"Price": "$12.50"`) {
+		t.Fatalf("Should use the converter of the verifier:\n%s", serialized)
+	}
+}
+
+func TestHasConverter(t *testing.T) {
+	s := newSettings(t)
+	AddConverter(cents(0), writeCents)(s)
+
+	value := cents(0)
+	if !s.hasConverter(reflect.TypeOf(value)) || !s.hasConverter(reflect.TypeOf(&value)) {
+		t.Fatalf("Should find the converter of %T and its pointer", value)
+	}
+	if s.hasConverter(reflect.TypeOf(priced{})) || s.hasConverter(nil) {
+		t.Fatalf("Should not find converters of other types")
+	}
+}
+
+type otherCents int64
+
+func TestAddConverter_KeyedByType(t *testing.T) {
+	serialized := serializeWithRules(t, struct {
+		Price cents
+		Other otherCents
+	}{Price: 1250, Other: 1250}, AddConverter(cents(0), writeCents))
+
+	if !strings.Contains(serialized, `"Other": 1250`) {
+		t.Fatalf("Should only convert the values of the type:\n%s", serialized)
+	}
+}
+
+func TestAddConverter_ErrorType(t *testing.T) {
+	verifiedPath := ""
+	NewVerifier(t,
+		UseDirectory(t.TempDir()),
+		AutoVerify(),
+		OnFirstVerify(func(file FilePair) {
+			verifiedPath = file.VerifiedPath
+		}),
+		AddConverter(codeError{}, func(value interface{}, writer *Writer) {
+			writer.WriteString(fmt.Sprintf("error %d", value.(codeError).Code))
+		}),
+	).Verify(&codeError{Code: 7})
+
+	if text := string(utils.File.ReadFile(verifiedPath)); text != "error 7" {
+		t.Fatalf("Should use the converter of the error type, got %s", text)
+	}
+}
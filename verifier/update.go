@@ -0,0 +1,46 @@
+package verifier
+
+import (
+	"github.com/VerifyTests/Verify.Go/diff"
+	"os"
+	"strings"
+)
+
+const (
+	envAutoVerify     = "VERIFY_AUTOVERIFY"
+	envAutoVerifyOnCI = "VERIFY_AUTOVERIFY_ON_CI"
+)
+
+// applyUpdateMode turns on AutoVerify when the `VERIFY_AUTOVERIFY` environment variable is set.
+// The test fails when a Continuous Integration environment is detected, unless `VERIFY_AUTOVERIFY_ON_CI` is also set.
+func applyUpdateMode(settings *verifySettings) {
+	if !isEnvEnabled(envAutoVerify) {
+		return
+	}
+
+	if settings.ciDetected == diff.Detected {
+		if !isEnvEnabled(envAutoVerifyOnCI) {
+			settings.t.Fatalf("The update mode was requested via `%s`, but a Continuous Integration environment was detected.\n"+
+				"To accept the received files on CI, also set `%s=1`.", envAutoVerify, envAutoVerifyOnCI)
+			return
+		}
+		settings.autoVerifyOnCI = true
+	}
+
+	settings.autoVerify = true
+}
+
+// acceptsChanges checks if the received files are accepted. AutoVerify doesn't accept the files on CI,
+// unless the update mode was explicitly allowed on CI.
+func (s *verifySettings) acceptsChanges() bool {
+	return s.autoVerify && (s.ciDetected == diff.NotDetected || s.autoVerifyOnCI)
+}
+
+func isEnvEnabled(key string) bool {
+	value, _ := os.LookupEnv(key)
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
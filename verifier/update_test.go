@@ -0,0 +1,112 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VerifyTests/Verify.Go/diff"
+	"github.com/VerifyTests/Verify.Go/utils"
+	"os"
+	"path"
+	"testing"
+)
+
+// fatalRecorder records the fatal failures instead of stopping the test
+type fatalRecorder struct {
+	*testing.T
+	fatal string
+}
+
+func (r *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	r.fatal = fmt.Sprintf(format, args...)
+}
+
+// setEnv sets the environment variable until the test completes
+func setEnv(t *testing.T, key, value string) {
+	previous, found := os.LookupEnv(key)
+	_ = os.Setenv(key, value)
+	t.Cleanup(func() {
+		if found {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func TestUpdateMode_Env(t *testing.T) {
+	setEnv(t, envAutoVerify, "1")
+
+	s := newSettings(t)
+	s.ciDetected = diff.NotDetected
+	applyUpdateMode(s)
+
+	if !s.autoVerify || !s.acceptsChanges() {
+		t.Fatalf("Should turn on AutoVerify")
+	}
+}
+
+func TestUpdateMode_RefusedOnCI(t *testing.T) {
+	setEnv(t, envAutoVerify, "true")
+	setEnv(t, envAutoVerifyOnCI, "0")
+
+	recorder := &fatalRecorder{T: t}
+	s := newSettings(recorder)
+	s.ciDetected = diff.Detected
+	applyUpdateMode(s)
+
+	if len(recorder.fatal) == 0 {
+		t.Fatalf("Should fail the test on CI")
+	}
+	if s.autoVerify {
+		t.Fatalf("Should not turn on AutoVerify on CI")
+	}
+}
+
+func TestUpdateMode_AllowedOnCI(t *testing.T) {
+	setEnv(t, envAutoVerify, "1")
+	setEnv(t, envAutoVerifyOnCI, "1")
+
+	s := newSettings(t)
+	s.ciDetected = diff.Detected
+	applyUpdateMode(s)
+
+	if !s.autoVerify || !s.acceptsChanges() {
+		t.Fatalf("Should turn on AutoVerify when it is allowed on CI")
+	}
+}
+
+func TestUpdateMode_NotRequested(t *testing.T) {
+	setEnv(t, envAutoVerify, "0")
+
+	s := newSettings(t)
+	applyUpdateMode(s)
+
+	if s.autoVerify {
+		t.Fatalf("Should not turn on AutoVerify")
+	}
+}
+
+func TestAutoVerify_IgnoredOnCI(t *testing.T) {
+	dir := t.TempDir()
+	indexed := path.Join(dir, "update_test.AutoVerify_IgnoredOnCI.01.verified.txt")
+	utils.File.WriteText(indexed, "no longer produced")
+
+	err := NewExtendedVerifier(t,
+		UseDirectory(dir),
+		AutoVerify(),
+		func(s *verifySettings) {
+			s.ciDetected = diff.Detected
+		},
+	).VerifyE("not accepted")
+
+	var verificationError *VerificationError
+	if !errors.As(err, &verificationError) || len(verificationError.NewFiles) != 1 || len(verificationError.DeletedFiles) != 1 {
+		t.Fatalf("Should report the changes that were not accepted on CI, got %v", err)
+	}
+	if utils.File.Exists(path.Join(dir, "update_test.AutoVerify_IgnoredOnCI.verified.txt")) {
+		t.Fatalf("Should not accept the received file on CI")
+	}
+	if !utils.File.Exists(indexed) {
+		t.Fatalf("Should not delete the verified files on CI")
+	}
+}
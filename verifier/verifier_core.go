@@ -2,6 +2,7 @@ package verifier
 
 import (
 	"io"
+	"net/http"
 	"reflect"
 )
 
@@ -11,14 +12,94 @@ type Verifier interface {
 	Configure(configure ...VerifyConfigure) Verifier
 }
 
+// ExtendedVerifier adds the other kinds of verification to Verifier. The verifiers created by NewVerifier
+// and NewExtendedVerifier implement it.
+type ExtendedVerifier interface {
+	Verifier
+	VerifyE(target interface{}) error
+	VerifyError(err error)
+	VerifyPanics(fun func())
+	VerifyHTTPResponse(response *http.Response)
+	VerifyHandler(handler http.Handler, request *http.Request)
+	VerifyCombinations(fun interface{}, inputs ...interface{})
+}
+
 // Verify verifies the passed target with the default settings.
 func Verify(t testingT, target interface{}) {
-	v := NewVerifier(t, nil)
+	v := NewVerifier(t)
+	v.Verify(target)
+}
+
+// VerifyE verifies the passed target with the default settings, and returns a *VerificationError instead of failing the test.
+func VerifyE(t testingT, target interface{}) error {
+	return newVerifier(t, nil).VerifyE(target)
+}
+
+// VerifyError verifies the error with the default settings. See Verifier.VerifyError.
+func VerifyError(t testingT, err error) {
+	newVerifier(t, nil).VerifyError(err)
+}
+
+// VerifyPanics verifies the value the function panics with, using the default settings. See Verifier.VerifyPanics.
+func VerifyPanics(t testingT, fun func()) {
+	newVerifier(t, nil).VerifyPanics(fun)
+}
+
+// VerifyError verifies the message, type and exported fields of the error, and of all the errors it wraps.
+// Stack traces in the messages are scrubbed.
+func (v *verifier) VerifyError(err error) {
+	if err == nil {
+		v.Verify(nil)
+		return
+	}
+
+	if v.settings.hasConverter(reflect.TypeOf(err)) {
+		v.Verify(err)
+		return
+	}
+
+	v.verifyWithStackTracesScrubbed(newErrorTarget(err))
+}
+
+// VerifyPanics runs the function and verifies the value it panics with. Errors are verified like VerifyError.
+// The test fails when the function doesn't panic.
+func (v *verifier) VerifyPanics(fun func()) {
+	recovered, panicked := capturePanic(fun)
+	if !panicked {
+		v.settings.t.Error("The function was expected to panic, but it returned normally.")
+		return
+	}
+
+	v.verifyWithStackTracesScrubbed(newPanicTarget(recovered))
+}
+
+func (v *verifier) verifyWithStackTracesScrubbed(target interface{}) {
+	scrubStackTraces := v.settings.scrubStackTraces
+	v.settings.scrubStackTraces = true
+	defer func() {
+		v.settings.scrubStackTraces = scrubStackTraces
+	}()
+
 	v.Verify(target)
 }
 
 // Verify verifies the passed target with the associated settings
 func (v *verifier) Verify(target interface{}) {
+	if err := v.VerifyE(target); err != nil {
+		v.settings.t.Error(err.Error())
+	}
+}
+
+// VerifyE verifies the passed target with the associated settings, and returns a *VerificationError when the
+// verification fails, instead of failing the test
+func (v *verifier) VerifyE(target interface{}) error {
+	if err := v.verify(target); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *verifier) verify(target interface{}) *VerificationError {
 
 	inner := createInnerVerifier(v.settings.t, v.settings)
 
@@ -26,8 +107,15 @@ func (v *verifier) Verify(target interface{}) {
 
 	if isNil(target) {
 		v.assertExtensionIsNull()
-		inner.verifyInner("nil", nil, emptyTargets)
-		return
+		return inner.verifyInner("nil", nil, emptyTargets)
+	}
+
+	if v.settings.hasConverter(reflect.TypeOf(target)) {
+		return inner.verifyInner(target, nil, emptyTargets)
+	}
+
+	if err, ok := target.(error); ok {
+		target = newErrorTarget(err)
 	}
 
 	if stringResult, ok := inner.tryGetToString(target); ok {
@@ -37,26 +125,22 @@ func (v *verifier) Verify(target interface{}) {
 
 		value := stringResult.Value
 		if len(value) == 0 {
-			inner.verifyInner("emptyString", nil, emptyTargets)
-			return
+			return inner.verifyInner("emptyString", nil, emptyTargets)
 		}
 
-		inner.verifyInner(value, nil, emptyTargets)
-		return
+		return inner.verifyInner(value, nil, emptyTargets)
 	}
 
 	if target, ok := target.(io.Reader); ok {
-		inner.verifyReader(target)
-		return
+		return inner.verifyReader(target)
 	}
 
 	if target, ok := target.([]byte); ok {
-		inner.verifyStream(target, "bin")
-		return
+		return inner.verifyStream(target, "bin")
 	}
 
 	v.assertExtensionIsNull()
-	inner.verifyInner(target, nil, emptyTargets)
+	return inner.verifyInner(target, nil, emptyTargets)
 }
 
 func (v *verifier) assertExtensionIsNull() {
@@ -64,7 +148,7 @@ func (v *verifier) assertExtensionIsNull() {
 		return
 	}
 
-	panic("`UseExtension` should only be used for text, for streams, or for converter discovery.\nWhen serializing an instance the default is txt.\nTo use json as an extension when serializing use `UseStrictJSON`")
+	panic("`UseExtension` should only be used for text, for streams, or for converter discovery.\nWhen serializing an instance the default is txt.\nTo use json as an extension when serializing use `UseStrictJSON`, or `UseYAML` for yaml")
 }
 
 type verifier struct {
@@ -74,20 +158,28 @@ type verifier struct {
 
 // Configure further configures the verifier
 func (v *verifier) Configure(configure ...VerifyConfigure) Verifier {
-	for _, cfg := range configure {
-		cfg(v.settings)
-	}
+	applyConfigure(v.settings, configure)
 	return v
 }
 
-// NewVerifier creates a new Verifier with the associated settings
+// NewVerifier creates a new Verifier with the settings of the `.verify.yaml` or `.verify.json` file,
+// the settings registered via Defaults, followed by the associated settings
 func NewVerifier(t testingT, configure ...VerifyConfigure) Verifier {
+	return newVerifier(t, configure)
+}
 
+// NewExtendedVerifier creates a new ExtendedVerifier, with the settings of NewVerifier
+func NewExtendedVerifier(t testingT, configure ...VerifyConfigure) ExtendedVerifier {
+	return newVerifier(t, configure)
+}
+
+func newVerifier(t testingT, configure []VerifyConfigure) *verifier {
 	var settings = newSettings(t)
 
-	for _, cfg := range configure {
-		cfg(settings)
-	}
+	applyUpdateMode(settings)
+	applyConfigFile(settings)
+	applyDefaults(settings)
+	applyConfigure(settings, configure)
 
 	return &verifier{
 		settings: settings,
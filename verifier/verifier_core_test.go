@@ -76,3 +76,22 @@ func TestRegisteringScrubberWithExtension(t *testing.T) {
 		}),
 	).Verify("alert(\"Hello World!\");")
 }
+
+func TestVerifyTwiceInSameTest(t *testing.T) {
+	v := verifier.NewVerifier(t,
+		verifier.UseDirectory("../_testdata"),
+	)
+
+	v.Verify("first")
+	v.Verify("second")
+}
+
+func TestDisableRequireUniquePrefix(t *testing.T) {
+	v := verifier.NewVerifier(t,
+		verifier.UseDirectory("../_testdata"),
+		verifier.DisableRequireUniquePrefix(),
+	)
+
+	v.Verify("same")
+	v.Verify("same")
+}
@@ -6,22 +6,33 @@ import (
 )
 
 // verifyStream verifies a target of type []byte
-func (v *innerVerifier) verifyStream(data []byte, extension string) {
+func (v *innerVerifier) verifyStream(data []byte, extension string) *VerificationError {
 
 	if len(data) == 0 {
 		panic("empty data is not allowed")
 	}
 
-	//TODO: Check for ExtensionConverter
+	var cleanup CleanupFunc
 
-	targets := []Target{*newStreamTarget(extension, data)}
+	if converter, ok := v.settings.tryGetFileConverter(extension); ok {
+		targets, info, err := v.convertStream(converter, data, extension)
+		if err != nil {
+			return &VerificationError{
+				TestName:          v.testing.Name(),
+				Directory:         v.outputDirectory,
+				ReceivedDirectory: v.receivedDirectory,
+				message:           err.Error(),
+			}
+		}
+		return v.verifyInner(info, cleanup, targets)
+	}
 
-	var cleanup CleanupFunc
+	targets := []Target{*newStreamTarget(extension, data)}
 
-	v.verifyInner(nil, cleanup, targets)
+	return v.verifyInner(nil, cleanup, targets)
 }
 
-func (v *innerVerifier) verifyReader(data io.Reader) {
+func (v *innerVerifier) verifyReader(data io.Reader) *VerificationError {
 	buff := new(bytes.Buffer)
 	_, err := buff.ReadFrom(data)
 	if err != nil {
@@ -35,5 +46,5 @@ func (v *innerVerifier) verifyReader(data io.Reader) {
 		ext = v.settings.extension
 	}
 
-	v.verifyStream(buff.Bytes(), ext)
+	return v.verifyStream(buff.Bytes(), ext)
 }
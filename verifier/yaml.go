@@ -0,0 +1,54 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"strings"
+)
+
+const yamlExtension = "yaml"
+
+// toYAML converts the serialized JSON to YAML. The JSON is parsed as a YAML document, which keeps the order
+// of the fields and the scrubbed values, and is written again in the block style.
+// Targets that weren't serialized as JSON, such as fmt.Stringer values, are returned as is, and the JSON is
+// returned when it can't be written as YAML.
+func toYAML(serialized string) string {
+	if !json.Valid([]byte(serialized)) {
+		return serialized
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(serialized), &document); err != nil {
+		return serialized
+	}
+	resetYAMLStyle(&document)
+
+	buffer := bytes.Buffer{}
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return serialized
+	}
+	_ = encoder.Close()
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// yaml11Scalars the plain scalars that YAML 1.1 readers load as booleans or numbers, while YAML 1.2 loads them
+// as strings, e.g. `yes`, `off` or `1:30`
+var yaml11Scalars = regexp.MustCompile(`^(y|Y|yes|Yes|YES|n|N|no|No|NO|on|On|ON|off|Off|OFF|` +
+	`[-+]?[0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?|[-+]?0b[01_]+|[-+]?0x[0-9a-fA-F_]+|[-+]?[0-9][0-9_]*_[0-9_]*)$`)
+
+// resetYAMLStyle removes the flow and quoting styles of JSON, so the encoder only quotes the strings that need it.
+// The strings that YAML 1.1 readers would load as another type stay quoted.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && yaml11Scalars.MatchString(node.Value) {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
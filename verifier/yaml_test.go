@@ -0,0 +1,50 @@
+package verifier
+
+import (
+	"testing"
+)
+
+func TestToYAML(t *testing.T) {
+	result := toYAML(`{"b": "true", "a": ["1", 1], "c": {}, "d": "text"}`)
+
+	expected := `b: "true"
+a:
+  - "1"
+  - 1
+c: {}
+d: text`
+	if result != expected {
+		t.Fatalf("Should keep the order of the fields and quote the strings when needed:\n%s", result)
+	}
+}
+
+func TestToYAML_QuotesYAML11Scalars(t *testing.T) {
+	result := toYAML(`{"a": "yes", "b": "off", "c": "1:30", "d": "0x1F", "e": "1_000", "f": "yesterday", "g": "db:5432"}`)
+
+	expected := `a: "yes"
+b: "off"
+c: "1:30"
+d: "0x1F"
+e: "1_000"
+f: yesterday
+g: db:5432`
+	if result != expected {
+		t.Fatalf("Should quote the strings that YAML 1.1 loads as other types:\n%s", result)
+	}
+}
+
+func TestToYAML_NotJSON(t *testing.T) {
+	if result := toYAML("key: value: x"); result != "key: value: x" {
+		t.Fatalf("Should not convert text that isn't JSON: %s", result)
+	}
+}
+
+func TestUseYAML_Extension(t *testing.T) {
+	s := newSettings(t)
+	UseStrictJSON()(s)
+	UseYAML()(s)
+
+	if s.serializedExtension() != yamlExtension {
+		t.Fatalf("Should use the yaml extension, got %s", s.serializedExtension())
+	}
+}